@@ -0,0 +1,216 @@
+package eclint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// baselineVersion is the version of the baseline file format.
+const baselineVersion = 1
+
+// ErrBaselineVersion is returned when the baseline file cannot be understood.
+var ErrBaselineVersion = errors.New("unsupported baseline version")
+
+// BaselineEntry is a grandfathered violation.
+//
+// It is keyed by the file, the rule and a hash of the line content rather
+// than the line number, so that unrelated edits don't invalidate it.
+type BaselineEntry struct {
+	Filename string `json:"filename"`
+	Rule     string `json:"rule"`
+	Hash     string `json:"hash"`
+	Count    int    `json:"count"`
+}
+
+type baselineFile struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+}
+
+type baselineKey struct {
+	filename string
+	rule     string
+	hash     string
+}
+
+// Baseline holds the known violations.
+//
+// It's not safe for concurrent use.
+type Baseline struct {
+	entries map[baselineKey]int
+	matched map[baselineKey]int
+	files   map[string]bool
+}
+
+// NewBaseline creates an empty baseline.
+func NewBaseline() *Baseline {
+	return &Baseline{
+		entries: make(map[baselineKey]int),
+		matched: make(map[baselineKey]int),
+		files:   make(map[string]bool),
+	}
+}
+
+// ReadBaseline reads a baseline, as written by Write.
+func ReadBaseline(r io.Reader) (*Baseline, error) {
+	var f baselineFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("cannot decode baseline: %w", err)
+	}
+
+	if f.Version != baselineVersion {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrBaselineVersion, f.Version, baselineVersion)
+	}
+
+	b := NewBaseline()
+
+	for _, e := range f.Entries {
+		count := e.Count
+		if count <= 0 {
+			count = 1
+		}
+
+		b.entries[baselineKey{filepath.ToSlash(e.Filename), e.Rule, e.Hash}] += count
+	}
+
+	return b, nil
+}
+
+// Add records the validation errors of the given file.
+func (b *Baseline) Add(filename string, errs []error) {
+	for _, err := range errs {
+		if key, ok := newBaselineKey(filename, err); ok {
+			b.entries[key]++
+		}
+	}
+}
+
+// Filter removes the errors found in the baseline.
+//
+// Each entry matches as many errors as it was recorded.
+func (b *Baseline) Filter(filename string, errs []error) []error {
+	b.files[filepath.ToSlash(filename)] = true
+
+	result := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		key, ok := newBaselineKey(filename, err)
+		if ok && b.matched[key] < b.entries[key] {
+			b.matched[key]++
+
+			continue
+		}
+
+		result = append(result, err)
+	}
+
+	return result
+}
+
+// Unmatched lists the entries that didn't match anything, they can be
+// removed from the baseline.
+//
+// Only the filtered files are considered, and the ones which no longer
+// exist, e.g. after being deleted or renamed.
+func (b *Baseline) Unmatched() []BaselineEntry {
+	unmatched := make([]BaselineEntry, 0)
+
+	for key, count := range b.entries {
+		if b.matched[key] >= count {
+			continue
+		}
+
+		if !b.files[key.filename] {
+			if _, err := os.Lstat(filepath.FromSlash(key.filename)); !errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+		}
+
+		unmatched = append(unmatched, BaselineEntry{
+			Filename: key.filename,
+			Rule:     key.rule,
+			Hash:     key.hash,
+			Count:    count - b.matched[key],
+		})
+	}
+
+	sortBaselineEntries(unmatched)
+
+	return unmatched
+}
+
+// Len returns the number of recorded violations.
+func (b *Baseline) Len() int {
+	n := 0
+	for _, count := range b.entries {
+		n += count
+	}
+
+	return n
+}
+
+// Write writes the baseline as JSON, the entries are sorted.
+func (b *Baseline) Write(w io.Writer) error {
+	entries := make([]BaselineEntry, 0, len(b.entries))
+	for key, count := range b.entries {
+		entries = append(entries, BaselineEntry{
+			Filename: key.filename,
+			Rule:     key.rule,
+			Hash:     key.hash,
+			Count:    count,
+		})
+	}
+
+	sortBaselineEntries(entries)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(baselineFile{Version: baselineVersion, Entries: entries}); err != nil {
+		return fmt.Errorf("cannot encode baseline: %w", err)
+	}
+
+	return nil
+}
+
+// newBaselineKey builds the key of a validation error.
+func newBaselineKey(filename string, err error) (baselineKey, bool) {
+	var ve ValidationError
+	if ok := errors.As(err, &ve); !ok {
+		return baselineKey{}, false
+	}
+
+	if ve.Filename != "" {
+		filename = ve.Filename
+	}
+
+	sum := sha256.Sum256([]byte(lineContent(ve.Line)))
+
+	return baselineKey{
+		filename: filepath.ToSlash(filename),
+		rule:     ve.Rule,
+		hash:     hex.EncodeToString(sum[:]),
+	}, true
+}
+
+func sortBaselineEntries(entries []BaselineEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+
+		return a.Hash < b.Hash
+	})
+}
@@ -0,0 +1,70 @@
+package eclint_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+func TestBaseline(t *testing.T) {
+	long := eclint.ValidationError{
+		Rule:  eclint.RuleMaxLineLength,
+		Line:  []byte("a very long line\n"),
+		Index: 3,
+	}
+	trailing := eclint.ValidationError{
+		Rule:  eclint.RuleTrimTrailingWhitespace,
+		Line:  []byte("hello \n"),
+		Index: 4,
+	}
+
+	b := eclint.NewBaseline()
+	b.Add("a.txt", []error{long, trailing, errors.New("random error")})
+	b.Add("b.txt", []error{long})
+	b.Add("baseline.go", []error{long})
+
+	if b.Len() != 4 {
+		t.Fatalf("four entries were expected, got %d", b.Len())
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := b.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := eclint.ReadBaseline(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the long line moved, the trailing whitespace was fixed, and a new one
+	// appeared.
+	moved := long
+	moved.Index = 10
+	fresh := long
+	fresh.Line = []byte("another very long line\n")
+
+	errs := b.Filter("a.txt", []error{moved, fresh, moved})
+	if len(errs) != 2 {
+		t.Fatalf("two errors were expected, got %d", len(errs))
+	}
+
+	// a.txt was filtered, b.txt is gone and baseline.go wasn't checked.
+	unmatched := b.Unmatched()
+	if len(unmatched) != 2 || unmatched[0].Rule != eclint.RuleTrimTrailingWhitespace || unmatched[1].Filename != "b.txt" {
+		t.Errorf("the trailing whitespace and the missing file should not match, got %+v", unmatched)
+	}
+}
+
+func TestReadBaselineFailure(t *testing.T) {
+	if _, err := eclint.ReadBaseline(strings.NewReader(`{"version": 42}`)); !errors.Is(err, eclint.ErrBaselineVersion) {
+		t.Errorf("a version error was expected, got %v", err)
+	}
+
+	if _, err := eclint.ReadBaseline(strings.NewReader(`[]`)); err == nil {
+		t.Error("an error was expected")
+	}
+}
@@ -0,0 +1,145 @@
+package eclint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hunkHeader matches the new file range of a unified diff hunk.
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// ChangedLines are the lines of a file added or modified by a diff.
+type ChangedLines struct {
+	// IsNew is set when the whole file is new.
+	IsNew bool
+	// Lines are the indexes, starting at zero, of the changed lines.
+	Lines map[int]bool
+	// LastLine tells whether the last line of the file was changed.
+	LastLine bool
+}
+
+// GitChangedLines finds the lines of the file changed since the base
+// revision, the working tree is compared to it.
+func GitChangedLines(ctx context.Context, base string, filename string) (*ChangedLines, error) {
+	// A file unknown to the base is entirely new.
+	err := exec.CommandContext(ctx, "git", "cat-file", "-e", base+":./"+filename).Run() //nolint:gosec
+	if err != nil {
+		var e *exec.ExitError
+		if ok := errors.As(err, &e); !ok {
+			return nil, fmt.Errorf("git cat-file failed: %w", err)
+		}
+
+		return &ChangedLines{IsNew: true, LastLine: true}, nil
+	}
+
+	output, err := exec.CommandContext( //nolint:gosec
+		ctx,
+		"git", "diff", "-U0", "--no-color", "--no-ext-diff", base, "--", filename,
+	).Output()
+	if err != nil {
+		var e *exec.ExitError
+		if ok := errors.As(err, &e); ok {
+			err = fmt.Errorf("git diff %s failed with %s: %w", base, bytes.TrimSpace(e.Stderr), e)
+		}
+
+		return nil, err
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s. %w", filename, err)
+	}
+
+	return ParseChangedLines(output, len(splitLines(content)))
+}
+
+// ParseChangedLines reads the hunks of a unified diff of a file having the
+// given number of lines.
+//
+// The pure deletions don't change any line of the new file. The file headers
+// are only looked for before the first hunk, as a deleted line may look
+// like one.
+func ParseChangedLines(diff []byte, lineCount int) (*ChangedLines, error) {
+	c := &ChangedLines{Lines: make(map[int]bool)}
+	inHunks := false
+
+	for _, line := range strings.Split(string(diff), "\n") {
+		if !inHunks && strings.HasPrefix(line, "--- /dev/null") {
+			c.IsNew = true
+			c.LastLine = true
+
+			continue
+		}
+
+		m := hunkHeader.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		inHunks = true
+
+		start, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hunk header %q: %w", line, err)
+		}
+
+		count := 1
+
+		if m[2] != "" {
+			count, err = strconv.Atoi(m[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid hunk header %q: %w", line, err)
+			}
+		}
+
+		for i := start; i < start+count; i++ {
+			c.Lines[i-1] = true
+
+			if i == lineCount {
+				c.LastLine = true
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// Filter keeps the errors found on the changed lines.
+//
+// The rules concerning the whole file, charset and insert_final_newline,
+// are only kept when the file is new or its last line changed. The errors
+// which aren't validation ones are always kept.
+func (c *ChangedLines) Filter(errs []error) []error {
+	if c.IsNew {
+		return errs
+	}
+
+	result := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		var ve ValidationError
+		if ok := errors.As(err, &ve); ok {
+			switch ve.Rule {
+			case RuleCharset, RuleInsertFinalNewline:
+				if !c.LastLine {
+					continue
+				}
+			default:
+				if !c.Lines[ve.Index] {
+					continue
+				}
+			}
+		}
+
+		result = append(result, err)
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package eclint_test
+
+import (
+	"errors"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+const changedDiff = `diff --git a/a.txt b/a.txt
+index 0123456..789abcd 100644
+--- a/a.txt
++++ b/a.txt
+@@ -2 +2 @@ a
+-b
++B
+@@ -5,0 +6,2 @@ d
++e
++f
+@@ -9,2 +10,0 @@ g
+-h
+-i
+`
+
+func TestParseChangedLines(t *testing.T) {
+	c, err := eclint.ParseChangedLines([]byte(changedDiff), 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.IsNew || c.LastLine {
+		t.Errorf("neither a new file nor a changed last line were expected, got %+v", c)
+	}
+
+	for _, i := range []int{1, 5, 6} {
+		if !c.Lines[i] {
+			t.Errorf("line %d was expected to be changed", i+1)
+		}
+	}
+
+	if len(c.Lines) != 3 {
+		t.Errorf("three changed lines were expected, got %v", c.Lines)
+	}
+
+	c, err = eclint.ParseChangedLines([]byte(changedDiff), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !c.LastLine {
+		t.Error("the last line was expected to be changed")
+	}
+
+	c, err = eclint.ParseChangedLines([]byte("--- /dev/null\n+++ b/a.txt\n@@ -0,0 +1 @@\n+a\n"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !c.IsNew {
+		t.Error("a new file was expected")
+	}
+
+	// The deleted line "-- /dev/null" isn't a file header.
+	c, err = eclint.ParseChangedLines([]byte("--- a/a.txt\n+++ b/a.txt\n@@ -1 +0,0 @@\n--- /dev/null\n"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.IsNew || c.LastLine || len(c.Lines) != 0 {
+		t.Errorf("only a deletion was expected, got %+v", c)
+	}
+}
+
+func TestChangedLinesFilter(t *testing.T) {
+	errs := []error{
+		eclint.ValidationError{Rule: eclint.RuleTrimTrailingWhitespace, Index: 1},
+		eclint.ValidationError{Rule: eclint.RuleTrimTrailingWhitespace, Index: 2},
+		eclint.ValidationError{Rule: eclint.RuleCharset, Index: 0},
+		eclint.ValidationError{Rule: eclint.RuleInsertFinalNewline, Index: 3},
+		errors.New("random error"),
+	}
+
+	c := &eclint.ChangedLines{Lines: map[int]bool{1: true}}
+	if result := c.Filter(errs); len(result) != 2 {
+		t.Errorf("the changed line and the other error were expected, got %v", result)
+	}
+
+	c = &eclint.ChangedLines{Lines: map[int]bool{3: true}, LastLine: true}
+	if result := c.Filter(errs); len(result) != 3 {
+		t.Errorf("the file level errors were expected, got %v", result)
+	}
+
+	c = &eclint.ChangedLines{IsNew: true}
+	if result := c.Filter(errs); len(result) != len(errs) {
+		t.Errorf("all the errors of a new file were expected, got %v", result)
+	}
+}
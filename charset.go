@@ -0,0 +1,142 @@
+package eclint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+const (
+	// utf8BomCharset is the internal name of utf-8-bom, see newDefinition.
+	utf8BomCharset = "utf-8 bom"
+	utf16leCharset = "utf-16le"
+	utf16beCharset = "utf-16be"
+)
+
+// ErrLossyConversion is returned when a file cannot be transcoded without
+// losing or replacing some characters.
+var ErrLossyConversion = errors.New("lossy charset conversion")
+
+// isTranscodable tells whether the fixer can convert files to the charset.
+func isTranscodable(charset string) bool {
+	switch charset {
+	case Utf8, utf8BomCharset, Latin1, utf16leCharset, utf16beCharset:
+		return true
+	}
+
+	return false
+}
+
+// probeLatin1 tells whether the bytes look like latin1 text, the control
+// characters other than the whitespaces are only expected in binary files.
+func probeLatin1(bs []byte) bool {
+	for _, b := range bs {
+		switch {
+		case b == tab, b == lf, b == '\v', b == '\f', b == cr:
+			continue
+		case b < 0x20, b >= 0x7f && b < 0xa0:
+			return false
+		}
+	}
+
+	return true
+}
+
+// detectSourceCharset finds the actual encoding of the content.
+//
+// The BOM wins, otherwise valid UTF-8 content is considered as such as it's
+// very unlikely for latin1 text to look like multi-byte sequences. Decoding
+// latin1 never loses anything, it's only refused for the control characters.
+func detectSourceCharset(data []byte) (string, error) {
+	if cs := detectCharsetUsingBOM(data); cs != "" {
+		if !isTranscodable(cs) {
+			return "", fmt.Errorf("%w: %s files are not supported", ErrLossyConversion, cs)
+		}
+
+		return cs, nil
+	}
+
+	if utf8.Valid(data) {
+		return Utf8, nil
+	}
+
+	if probeLatin1(data) {
+		return Latin1, nil
+	}
+
+	return "", fmt.Errorf("%w: the content is neither valid utf-8 nor latin1", ErrLossyConversion)
+}
+
+// decodeCharset converts the content into UTF-8, without any BOM.
+//
+// The conversion is refused unless encoding the result back gives the
+// original content.
+func decodeCharset(data []byte, charset string) ([]byte, error) {
+	var text []byte
+
+	switch charset {
+	case Utf8:
+		text = data
+	case utf8BomCharset:
+		text = bytes.TrimPrefix(data, utf8Bom)
+	default:
+		e, err := charsetEncoding(charset)
+		if err != nil {
+			return nil, err
+		}
+
+		text, err = e.NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cannot decode from %s: %w", ErrLossyConversion, charset, err)
+		}
+	}
+
+	back, err := encodeCharset(text, charset)
+	if err != nil || !bytes.Equal(back, data) {
+		return nil, fmt.Errorf("%w: the content is not valid %s", ErrLossyConversion, charset)
+	}
+
+	return text, nil
+}
+
+// encodeCharset converts the UTF-8 content into the given charset.
+func encodeCharset(text []byte, charset string) ([]byte, error) {
+	switch charset {
+	case Utf8:
+		return text, nil
+	case utf8BomCharset:
+		return append(append([]byte{}, utf8Bom...), text...), nil
+	}
+
+	e, err := charsetEncoding(charset)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := e.NewEncoder().Bytes(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot encode into %s: %w", ErrLossyConversion, charset, err)
+	}
+
+	return data, nil
+}
+
+// charsetEncoding returns the encoding of the non UTF-8 charsets, the
+// UTF-16 ones are written with a BOM.
+func charsetEncoding(charset string) (encoding.Encoding, error) {
+	switch charset {
+	case Latin1:
+		return charmap.ISO8859_1, nil
+	case utf16leCharset:
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), nil
+	case utf16beCharset:
+		return unicode.UTF16(unicode.BigEndian, unicode.UseBOM), nil
+	}
+
+	return nil, fmt.Errorf("%w: cannot convert to %q", ErrNotImplemented, charset)
+}
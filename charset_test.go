@@ -0,0 +1,158 @@
+package eclint
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFixCharset(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Charset  string
+		File     []byte
+		Expected []byte
+		Fixed    bool
+	}{
+		{
+			Name:     "strip utf-8 bom",
+			Charset:  "utf-8",
+			File:     []byte("\xef\xbb\xbfhello\n"),
+			Expected: []byte("hello\n"),
+			Fixed:    true,
+		}, {
+			Name:     "add utf-8 bom",
+			Charset:  "utf-8-bom",
+			File:     []byte("hello\n"),
+			Expected: []byte("\xef\xbb\xbfhello\n"),
+			Fixed:    true,
+		}, {
+			Name:     "keep utf-8 bom",
+			Charset:  "utf-8-bom",
+			File:     []byte("\xef\xbb\xbfhello\n"),
+			Expected: []byte("\xef\xbb\xbfhello\n"),
+		}, {
+			Name:     "latin1 to utf-8",
+			Charset:  "utf-8",
+			File:     []byte("Le caf\xe9 est tr\xe8s chaud \xe0 midi, d\xe9j\xe0.\n"),
+			Expected: []byte("Le café est très chaud à midi, déjà.\n"),
+			Fixed:    true,
+		}, {
+			Name:     "short latin1 to utf-8",
+			Charset:  "utf-8",
+			File:     []byte("caf\xe9\n"),
+			Expected: []byte("café\n"),
+			Fixed:    true,
+		}, {
+			Name:     "utf-8 to latin1",
+			Charset:  "latin1",
+			File:     []byte("Le café est très chaud à midi, déjà.\n"),
+			Expected: []byte("Le caf\xe9 est tr\xe8s chaud \xe0 midi, d\xe9j\xe0.\n"),
+			Fixed:    true,
+		}, {
+			Name:     "utf-16le to utf-8",
+			Charset:  "utf-8",
+			File:     []byte("\xff\xfeh\x00i\x00\n\x00"),
+			Expected: []byte("hi\n"),
+			Fixed:    true,
+		}, {
+			Name:     "utf-8 to utf-16be",
+			Charset:  "utf-16be",
+			File:     []byte("hi\n"),
+			Expected: []byte("\xfe\xff\x00h\x00i\x00\n"),
+			Fixed:    true,
+		}, {
+			Name:     "utf-16be",
+			Charset:  "utf-16be",
+			File:     []byte("\xfe\xff\x00h\x00i\x00\n"),
+			Expected: []byte("\xfe\xff\x00h\x00i\x00\n"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			result, fixed, err := fixCharset(t, tc.Charset, tc.File)
+			if err != nil {
+				t.Fatalf("no errors were expected, got %s", err)
+			}
+
+			if fixed != tc.Fixed {
+				t.Errorf("fixed %v was expected, got %v", tc.Fixed, fixed)
+			}
+
+			if !cmp.Equal(tc.Expected, result) {
+				t.Errorf("diff %s", cmp.Diff(tc.Expected, result))
+			}
+		})
+	}
+}
+
+func TestFixCharsetLossy(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Charset string
+		File    []byte
+	}{
+		{
+			Name:    "euro to latin1",
+			Charset: "latin1",
+			File:    []byte("It costs 10€.\n"),
+		}, {
+			Name:    "truncated utf-16le",
+			Charset: "utf-8",
+			File:    []byte("\xff\xfeh\x00i\x00\n"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := fixCharset(t, tc.Charset, tc.File)
+			if !errors.Is(err, ErrLossyConversion) {
+				t.Errorf("a lossy conversion error was expected, got %v", err)
+			}
+		})
+	}
+}
+
+func fixCharset(t *testing.T, charset string, content []byte) ([]byte, bool, error) {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(filename, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	def, err := newDefinition(&editorconfig.Definition{
+		Charset:   charset,
+		EndOfLine: editorconfig.EndOfLineLf,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, changes, err := fixWithFilename(context.TODO(), def, filename, int64(len(content)))
+	if err != nil {
+		return nil, false, err
+	}
+
+	result, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result, len(changes) != 0, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+
+	"gitlab.com/greut/eclint"
+)
+
+const checkConfigCommand = "check-config"
+
+// processCheckConfig validates the .editorconfig files found within the
+// paths, the current directory by default.
+//
+// It returns the number of errors found.
+func processCheckConfig(ctx context.Context, opt *eclint.Option, reporter eclint.Reporter, args []string) (int, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	c := 0
+	fileChan, errChan := eclint.WalkContext(ctx, args...)
+
+	for filename := range fileChan {
+		if filepath.Base(filename) != eclint.ConfigFilename {
+			continue
+		}
+
+		errs := eclint.CheckConfig(ctx, opt.Rules, filename)
+		c += eclint.CountErrors(errs)
+
+		if err := reporter.Report(ctx, filename, errs); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := <-errChan; err != nil {
+		return 0, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"gitlab.com/greut/eclint"
+)
+
+const explainCommand = "explain"
+
+var errExplain = errors.New("explain expects the paths of the files")
+
+// processExplain prints where the definition of each file comes from.
+func processExplain(ctx context.Context, opt *eclint.Option, args []string) error {
+	if len(args) == 0 {
+		return errExplain
+	}
+
+	for _, filename := range args {
+		e, err := eclint.Explain(filename, overridePrefix)
+		if err != nil {
+			return err
+		}
+
+		if err := eclint.PrintExplanation(ctx, opt, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"gitlab.com/greut/eclint"
+)
+
+const (
+	installHookCommand = "install-hook"
+	hookMarker         = "# installed by eclint install-hook"
+)
+
+// hookScript checks the staged content before each commit.
+const hookScript = `#!/bin/sh
+` + hookMarker + `
+exec eclint -staged
+`
+
+var errHookExists = errors.New("a pre-commit hook, not installed by eclint, already exists")
+
+// installHook writes the pre-commit hook of the current git repository.
+//
+// A hook previously installed by eclint is replaced, any other one is kept.
+func installHook(ctx context.Context, opt *eclint.Option) error {
+	output, err := exec.CommandContext(ctx, "git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		var e *exec.ExitError
+		if ok := errors.As(err, &e); ok {
+			err = fmt.Errorf("git rev-parse failed with %s: %w", bytes.TrimSpace(e.Stderr), e)
+		}
+
+		return err
+	}
+
+	// The hooks directory may be missing, e.g. when set using core.hooksPath.
+	dir := string(bytes.TrimSpace(output))
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec
+		return fmt.Errorf("cannot create %s. %w", dir, err)
+	}
+
+	filename := filepath.Join(dir, "pre-commit")
+
+	content, err := os.ReadFile(filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot read %s. %w", filename, err)
+	}
+
+	if err == nil && !bytes.Contains(content, []byte(hookMarker)) {
+		return fmt.Errorf("%s: %w", filename, errHookExists)
+	}
+
+	if err := os.WriteFile(filename, []byte(hookScript), 0o755); err != nil { //nolint:gosec
+		return fmt.Errorf("cannot write %s. %w", filename, err)
+	}
+
+	// WriteFile keeps the mode of an existing file.
+	if err := os.Chmod(filename, 0o755); err != nil { //nolint:gosec
+		return fmt.Errorf("cannot chmod %s. %w", filename, err)
+	}
+
+	fmt.Fprintf(opt.Stdout, "pre-commit hook installed into %s\n", filename)
+
+	return nil
+}
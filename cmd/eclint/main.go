@@ -2,14 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"runtime"
 	"runtime/pprof"
+	"strings"
 	"syscall"
 
-	"github.com/editorconfig/editorconfig-core-go/v2"
 	"github.com/go-logr/logr"
 	"github.com/mattn/go-colorable"
 	"gitlab.com/greut/eclint"
@@ -24,9 +25,19 @@ const (
 	overridePrefix = "eclint_"
 )
 
+var (
+	errStdin   = errors.New("-stdin-filename and - must be used together")
+	errChanged = errors.New("-changed-since and -uncommitted cannot be used with paths")
+	errStaged  = errors.New("-staged cannot be used with paths, -fix or the other git modes")
+	errFiles   = errors.New("-files-from cannot be used with paths or the other listing modes")
+)
+
 func main() { //nolint:funlen
 	flagVersion := false
+	flagDiff := false
 	color := "auto"
+	disable := ""
+	warn := ""
 	cpuprofile := ""
 	memprofile := ""
 
@@ -54,7 +65,21 @@ func main() { //nolint:funlen
 	flag.BoolVar(&flagVersion, "version", false, "print the version number")
 	flag.StringVar(&color, "color", color, `use color when printing; can be "always", "auto", or "never"`)
 	flag.BoolVar(&opt.Summary, "summary", opt.Summary, "enable the summary view")
+	flag.StringVar(
+		&opt.Format,
+		"format",
+		opt.Format,
+		fmt.Sprintf("output format, one of %q (detected from the CI environment when empty)", eclint.Formats()),
+	)
 	flag.BoolVar(&opt.FixAllErrors, "fix", opt.FixAllErrors, "enable fixing instead of error reporting")
+	flag.BoolVar(&opt.DryRun, "dry-run", opt.DryRun, "with -fix, print the changes as a unified diff instead")
+	flag.BoolVar(&flagDiff, "diff", flagDiff, "same as -fix -dry-run")
+	flag.StringVar(
+		&opt.BackupSuffix,
+		"backup-suffix",
+		opt.BackupSuffix,
+		"with -fix, keep a copy of the original files using this `suffix` (e.g. .orig)",
+	)
 	flag.BoolVar(
 		&opt.ShowAllErrors,
 		"show_all_errors",
@@ -67,7 +92,51 @@ func main() { //nolint:funlen
 		opt.ShowErrorQuantity,
 		"display only the first n errors (0 means all)",
 	)
-	flag.StringVar(&opt.Exclude, "exclude", opt.Exclude, "paths to exclude")
+	flag.Var((*stringList)(&opt.Excludes), "exclude", "exclude the paths matching this `pattern`, can be repeated")
+	flag.Var((*stringList)(&opt.Includes), "include", "only check the paths matching this `pattern`, can be repeated")
+	flag.StringVar(
+		&opt.ChangedSince,
+		"changed-since",
+		opt.ChangedSince,
+		"only check the files added, copied, modified or renamed since the given git `ref`",
+	)
+	flag.BoolVar(&opt.Uncommitted, "uncommitted", opt.Uncommitted, "only check the files having uncommitted changes")
+	flag.BoolVar(
+		&opt.DiffLinesOnly,
+		"diff-lines-only",
+		opt.DiffLinesOnly,
+		"only report the errors of the lines changed since the -changed-since ref (or HEAD)",
+	)
+	flag.BoolVar(
+		&opt.Untracked,
+		"untracked",
+		opt.Untracked,
+		"when no paths are given, check the untracked files which aren't ignored as well",
+	)
+	flag.StringVar(
+		&opt.FilesFrom,
+		"files-from",
+		opt.FilesFrom,
+		"check the files listed in this `file`, one per line, - being the standard input",
+	)
+	flag.BoolVar(&opt.NulSeparated, "0", opt.NulSeparated, "with -files-from, the files are separated by NUL characters")
+	flag.BoolVar(&opt.Staged, "staged", opt.Staged, "check the staged content of the files, e.g. from a pre-commit hook")
+	flag.StringVar(
+		&opt.StdinFilename,
+		"stdin-filename",
+		opt.StdinFilename,
+		"read the content from the standard input, given as -, using the definition of this `path`",
+	)
+	flag.IntVar(&opt.Jobs, "jobs", runtime.GOMAXPROCS(0), "number of files processed concurrently")
+	flag.StringVar(&disable, "disable", disable, "comma separated list of rules to disable")
+	flag.StringVar(&warn, "warn", warn, "comma separated list of rules only reported as warnings")
+	flag.StringVar(&opt.Baseline, "baseline", opt.Baseline, "only report the violations missing from the baseline `file`")
+	flag.StringVar(
+		&opt.WriteBaseline,
+		"write-baseline",
+		opt.WriteBaseline,
+		"record the current violations into the baseline `file`",
+	)
 	flag.StringVar(&cpuprofile, "cpuprofile", cpuprofile, "write cpu profile to `file`")
 	flag.StringVar(&memprofile, "memprofile", memprofile, "write mem profile to `file`")
 	flag.Parse()
@@ -85,6 +154,11 @@ func main() { //nolint:funlen
 		opt.NoColors = true
 	}
 
+	if flagDiff {
+		opt.FixAllErrors = true
+		opt.DryRun = true
+	}
+
 	if opt.Summary {
 		opt.ShowAllErrors = true
 	}
@@ -93,14 +167,107 @@ func main() { //nolint:funlen
 		opt.ShowErrorQuantity = 0
 	}
 
+	excludes := opt.Excludes
 	if opt.Exclude != "" {
-		_, err := editorconfig.FnmatchCase(opt.Exclude, "dummy")
-		if err != nil {
-			log.Error(err, "exclude pattern failure", "exclude", opt.Exclude)
-			flag.Usage()
+		excludes = append([]string{opt.Exclude}, excludes...)
+	}
 
-			return
+	filter, err := eclint.NewFileFilter(".", excludes, opt.Includes)
+	if err != nil {
+		log.Error(err, "exclude or include pattern failure", "exclude", excludes, "include", opt.Includes)
+		flag.Usage()
+
+		return
+	}
+
+	args := flag.Args()
+
+	if len(args) == 1 && args[0] == installHookCommand {
+		if err := installHook(context.Background(), opt); err != nil {
+			log.Error(err, "cannot install the hook")
+
+			retcode = 2
+		}
+
+		return
+	}
+
+	if len(args) > 0 && args[0] == explainCommand {
+		ctx := logr.NewContext(context.Background(), log)
+
+		if err := processExplain(ctx, opt, args[1:]); err != nil {
+			log.Error(err, "cannot explain the definition", "args", args[1:])
+
+			retcode = 2
 		}
+
+		return
+	}
+
+	checkConfig := len(args) > 0 && args[0] == checkConfigCommand
+	if checkConfig {
+		args = args[1:]
+	}
+
+	stdin := len(args) == 1 && args[0] == "-"
+
+	if stdin != (opt.StdinFilename != "") {
+		log.Error(errStdin, "invalid arguments", "args", args)
+		flag.Usage()
+
+		return
+	}
+
+	if len(args) > 0 && (opt.ChangedSince != "" || opt.Uncommitted) {
+		log.Error(errChanged, "invalid arguments", "args", args)
+		flag.Usage()
+
+		return
+	}
+
+	if opt.Staged && (len(args) > 0 || opt.FixAllErrors || opt.ChangedSince != "" || opt.Uncommitted || opt.DiffLinesOnly) {
+		log.Error(errStaged, "invalid arguments", "args", args)
+		flag.Usage()
+
+		return
+	}
+
+	if opt.FilesFrom != "" && (len(args) > 0 || opt.ChangedSince != "" || opt.Uncommitted || opt.Staged) {
+		log.Error(errFiles, "invalid arguments", "args", args)
+		flag.Usage()
+
+		return
+	}
+
+	newRuleSet := eclint.NewRuleSet
+	newReporter := eclint.NewReporter
+
+	if checkConfig {
+		newRuleSet = eclint.NewConfigRuleSet
+		newReporter = eclint.NewConfigReporter
+	}
+
+	rules, err := newRuleSet(splitList(disable), splitList(warn))
+	if err != nil {
+		log.Error(err, "rules failure", "disable", disable, "warn", warn)
+		flag.Usage()
+
+		return
+	}
+
+	opt.Rules = rules
+
+	if opt.Format == "" {
+		opt.Format = eclint.DetectFormat(os.Getenv)
+		log.V(1).Info("output format detected", "format", opt.Format)
+	}
+
+	reporter, err := newReporter(opt)
+	if err != nil {
+		log.Error(err, "output format failure", "format", opt.Format)
+		flag.Usage()
+
+		return
 	}
 
 	if cpuprofile != "" {
@@ -122,7 +289,17 @@ func main() { //nolint:funlen
 
 	ctx := logr.NewContext(context.Background(), log)
 
-	c, err := processArgs(ctx, opt, flag.Args())
+	var c int
+
+	switch {
+	case checkConfig:
+		c, err = processCheckConfig(ctx, opt, reporter, args)
+	case stdin:
+		c, err = processStdin(ctx, opt, reporter, os.Stdin)
+	default:
+		c, err = processArgs(ctx, opt, reporter, filter, args)
+	}
+
 	if err != nil {
 		log.Error(err, "linting failure")
 
@@ -131,6 +308,18 @@ func main() { //nolint:funlen
 		return
 	}
 
+	// When fixing the standard input, the output is the fixed content, and
+	// in dry-run mode it's the diff, the reporter has nothing to add to them.
+	if !opt.FixAllErrors || (!stdin && !opt.DryRun) {
+		if err := reporter.Close(ctx); err != nil {
+			log.Error(err, "reporting failure")
+
+			retcode = 2
+
+			return
+		}
+	}
+
 	if memprofile != "" {
 		f, err := os.Create(memprofile)
 		if err != nil {
@@ -150,87 +339,40 @@ func main() { //nolint:funlen
 	}
 
 	if c > 0 {
-		log.V(1).Info("some errors were found.", "count", c)
+		log.V(1).Info("some errors were found, or files would be fixed.", "count", c)
 
 		retcode = 1
 	}
 }
 
-func processArgs(ctx context.Context, opt *eclint.Option, args []string) (int, error) { //nolint:funlen,gocognit
-	log := logr.FromContextOrDiscard(ctx)
-	c := 0
+// stringList is a flag which can be repeated.
+type stringList []string
 
-	config := &editorconfig.Config{
-		Parser: editorconfig.NewCachedParser(),
+// String returns the values separated by commas.
+func (l *stringList) String() string {
+	if l == nil {
+		return ""
 	}
 
-	fileChan, errChan := eclint.ListFilesContext(ctx, args...)
-
-	for {
-		select {
-		case <-ctx.Done():
-			return 0, ctx.Err()
-
-		case err, ok := <-errChan:
-			if ok {
-				log.Error(err, "cannot list files")
-
-				return 0, err
-			}
-
-		case filename, ok := <-fileChan:
-			if !ok {
-				return c, nil
-			}
-
-			log := log.WithValues("filename", filename)
-
-			// Skip excluded files
-			if opt.Exclude != "" {
-				ok, err := editorconfig.FnmatchCase(opt.Exclude, filename)
-				if err != nil {
-					log.Error(err, "exclude pattern failure", "exclude", opt.Exclude)
-
-					return 0, err
-				}
-
-				if ok {
-					continue
-				}
-			}
-
-			def, err := config.Load(filename)
-			if err != nil {
-				log.Error(err, "cannot open file")
-
-				return 0, err
-			}
-
-			err = eclint.OverrideDefinitionUsingPrefix(def, overridePrefix)
-			if err != nil {
-				log.Error(err, "overriding the definition failed", "prefix", overridePrefix)
-
-				return 0, err
-			}
+	return strings.Join(*l, ",")
+}
 
-			// Linting vs Fixing
-			if !opt.FixAllErrors {
-				errs := eclint.LintWithDefinition(ctx, def, filename)
-				c += len(errs)
+// Set adds the value.
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
 
-				if err := eclint.PrintErrors(ctx, opt, filename, errs); err != nil {
-					log.Error(err, "print errors failure")
+	return nil
+}
 
-					return 0, err
-				}
-			} else {
-				err := eclint.FixWithDefinition(ctx, def, filename)
-				if err != nil {
-					log.Error(err, "fixing errors failure")
+// splitList splits the comma separated values, dropping the empty ones.
+func splitList(s string) []string {
+	values := make([]string, 0)
 
-					return 0, err
-				}
-			}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
 		}
 	}
+
+	return values
 }
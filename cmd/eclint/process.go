@@ -0,0 +1,606 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+	"github.com/go-logr/logr"
+	"gitlab.com/greut/eclint"
+)
+
+// job is a file to process, the index is its position in the listing.
+type job struct {
+	index    int
+	filename string
+	attrs    eclint.Attributes
+}
+
+// result is the outcome of a job.
+type result struct {
+	job
+	errs   []error
+	diff   []byte
+	report *eclint.FixReport
+	err    error
+}
+
+// processArgs lints, or fixes, the files concurrently.
+//
+// The results are handled in the order the files were listed so that the
+// output is reproducible. It returns the number of errors found or, in dry-run
+// mode, the number of files that would be fixed.
+func processArgs( //nolint:funlen,gocognit
+	ctx context.Context,
+	opt *eclint.Option,
+	reporter eclint.Reporter,
+	filter *eclint.FileFilter,
+	args []string,
+) (int, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	c := 0
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	config := eclint.NewCachedConfig()
+
+	base, err := diffBase(ctx, opt)
+	if err != nil {
+		log.Error(err, "cannot find the base revision", "ref", opt.ChangedSince)
+
+		return 0, err
+	}
+
+	baseline, err := loadBaseline(opt)
+	if err != nil {
+		log.Error(err, "cannot load the baseline", "baseline", opt.Baseline)
+
+		return 0, err
+	}
+
+	attributes, err := eclint.NewGitAttributes(".")
+	if err != nil {
+		log.Error(err, "cannot load the git attributes")
+
+		return 0, err
+	}
+
+	list, err := openFilesFrom(opt)
+	if err != nil {
+		return 0, err
+	}
+
+	if list != nil {
+		defer list.Close()
+	}
+
+	fileChan, errChan := listFiles(ctx, opt, args, list)
+
+	jobs := make(chan job)
+	listErr := make(chan error, 1)
+
+	go func() {
+		defer close(jobs)
+
+		listErr <- listJobs(ctx, filter, attributes, fileChan, errChan, jobs)
+	}()
+
+	workers := opt.Jobs
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make(chan result)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := range jobs {
+				select {
+				case results <- processFile(ctx, opt, config, base, j):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	next := 0
+	pending := make(map[int]result)
+
+	for r := range results {
+		pending[r.index] = r
+
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+
+			delete(pending, next)
+			next++
+
+			if r.err != nil {
+				return 0, r.err
+			}
+
+			if opt.FixAllErrors {
+				if len(r.diff) > 0 {
+					c++
+
+					if _, err := opt.Stdout.Write(r.diff); err != nil {
+						return 0, err
+					}
+				}
+
+				if err := reportFixes(ctx, reporter, r.report); err != nil {
+					return 0, err
+				}
+
+				continue
+			}
+
+			errs := r.errs
+
+			if opt.WriteBaseline != "" {
+				baseline.Add(r.filename, errs)
+
+				continue
+			}
+
+			if baseline != nil {
+				errs = baseline.Filter(r.filename, errs)
+			}
+
+			c += eclint.CountErrors(errs)
+
+			if err := reporter.Report(ctx, r.filename, errs); err != nil {
+				log.Error(err, "report errors failure", "filename", r.filename)
+
+				return 0, err
+			}
+		}
+	}
+
+	if err := <-listErr; err != nil {
+		return 0, err
+	}
+
+	return c, saveBaseline(ctx, opt, baseline)
+}
+
+// listFiles picks the source of the files to process.
+func listFiles(ctx context.Context, opt *eclint.Option, args []string, list io.Reader) (<-chan string, <-chan error) {
+	if list != nil {
+		return eclint.ReadFilesContext(ctx, list, opt.NulSeparated)
+	}
+
+	if opt.Staged {
+		return eclint.GitStagedFilesContext(ctx)
+	}
+
+	if opt.ChangedSince != "" || opt.Uncommitted {
+		return eclint.GitDiffFilesContext(ctx, opt.ChangedSince, opt.Uncommitted)
+	}
+
+	if len(args) == 0 {
+		return eclint.GitLsFilesContext(ctx, ".", opt.Untracked)
+	}
+
+	return eclint.WalkContext(ctx, args...)
+}
+
+// openFilesFrom opens the list of files given using -files-from, if any.
+func openFilesFrom(opt *eclint.Option) (io.ReadCloser, error) {
+	switch opt.FilesFrom {
+	case "":
+		return nil, nil //nolint:nilnil
+	case "-":
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	fp, err := os.Open(opt.FilesFrom)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open the list of files: %w", err)
+	}
+
+	return fp, nil
+}
+
+// listJobs numbers the listed files, skipping the excluded ones and the ones
+// git considers binary, generated or vendored.
+func listJobs( //nolint:cyclop
+	ctx context.Context,
+	filter *eclint.FileFilter,
+	attributes *eclint.GitAttributes,
+	fileChan <-chan string,
+	errChan <-chan error,
+	jobs chan<- job,
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+	index := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case err, ok := <-errChan:
+			if !ok {
+				errChan = nil
+
+				continue
+			}
+
+			log.Error(err, "cannot list files")
+
+			return err
+
+		case filename, ok := <-fileChan:
+			if !ok {
+				return nil
+			}
+
+			skip, reason, err := filter.Skip(filename)
+			if err != nil {
+				log.Error(err, "exclude or include pattern failure", "filename", filename)
+
+				return err
+			}
+
+			if skip {
+				log.V(2).Info("skipped file", "filename", filename, "reason", reason)
+
+				continue
+			}
+
+			attrs, err := attributes.Get(filename)
+			if err != nil {
+				log.Error(err, "cannot resolve the git attributes", "filename", filename)
+
+				return err
+			}
+
+			if skip, reason := attrs.Skip(); skip {
+				log.V(2).Info("skipped file", "filename", filename, "reason", reason)
+
+				continue
+			}
+
+			if reason != "" {
+				log.V(2).Info("kept file", "filename", filename, "reason", reason)
+			}
+
+			select {
+			case jobs <- job{index: index, filename: filename, attrs: attrs}:
+				index++
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// diffBase finds the revision the lines are compared to with -diff-lines-only,
+// it's the merge base of the -changed-since ref or HEAD.
+func diffBase(ctx context.Context, opt *eclint.Option) (string, error) {
+	if !opt.DiffLinesOnly || opt.FixAllErrors {
+		return "", nil
+	}
+
+	if opt.ChangedSince == "" {
+		return "HEAD", nil
+	}
+
+	output, err := exec.CommandContext(ctx, "git", "merge-base", opt.ChangedSince, "HEAD").Output()
+	if err != nil {
+		var e *exec.ExitError
+		if ok := errors.As(err, &e); ok {
+			err = fmt.Errorf("git merge-base failed with %s: %w", bytes.TrimSpace(e.Stderr), e)
+		}
+
+		return "", err
+	}
+
+	return string(bytes.TrimSpace(output)), nil
+}
+
+// processFile lints, or fixes, a single file.
+//
+// When a base revision is given, only the errors of the lines changed since
+// then are kept, the eol attribute conflict included as it's reported on the
+// first line. With -staged, the content is read from the index.
+func processFile(
+	ctx context.Context,
+	opt *eclint.Option,
+	config *eclint.CachedConfig,
+	base string,
+	j job,
+) result {
+	log := logr.FromContextOrDiscard(ctx).WithValues("filename", j.filename)
+	r := result{job: j}
+
+	def, err := config.Load(j.filename)
+	if err != nil {
+		log.Error(err, "cannot open file")
+
+		r.err = err
+
+		return r
+	}
+
+	err = eclint.OverrideDefinitionUsingPrefix(def, overridePrefix)
+	if err != nil {
+		log.Error(err, "overriding the definition failed", "prefix", overridePrefix)
+
+		r.err = err
+
+		return r
+	}
+
+	switch {
+	case opt.Staged:
+		return lintStagedFile(ctx, opt, def, r)
+	case opt.FixAllErrors:
+		return fixFile(ctx, opt, def, r)
+	default:
+		return lintFile(ctx, opt, def, base, r)
+	}
+}
+
+// lintFile lints the file, keeping only the errors of the changed lines when
+// a base revision is given.
+func lintFile(ctx context.Context, opt *eclint.Option, def *editorconfig.Definition, base string, r result) result {
+	r.errs = eclint.LintWithDefinition(ctx, opt.Rules, def, r.filename)
+	r.errs = checkGitAttributes(opt, def, r.job, r.errs)
+
+	if base == "" || len(r.errs) == 0 {
+		return r
+	}
+
+	changed, err := eclint.GitChangedLines(ctx, base, r.filename)
+	if err != nil {
+		log := logr.FromContextOrDiscard(ctx).WithValues("filename", r.filename)
+		log.Error(err, "cannot find the changed lines", "base", base)
+
+		r.err = err
+
+		return r
+	}
+
+	r.errs = changed.Filter(r.errs)
+
+	return r
+}
+
+// lintStagedFile lints the content of the file found in the index.
+func lintStagedFile(ctx context.Context, opt *eclint.Option, def *editorconfig.Definition, r result) result {
+	content, err := eclint.GitStagedContent(ctx, r.filename)
+	if err != nil {
+		log := logr.FromContextOrDiscard(ctx).WithValues("filename", r.filename)
+		log.Error(err, "cannot read the staged content")
+
+		r.err = err
+
+		return r
+	}
+
+	r.errs = eclint.LintReader(ctx, opt.Rules, def, r.filename, bytes.NewReader(content), int64(len(content)))
+	r.errs = checkGitAttributes(opt, def, r.job, r.errs)
+
+	return r
+}
+
+// fixFile fixes the file in place, or computes the diff of the fixes with
+// -dry-run. A file whose charset cannot be converted is skipped.
+func fixFile(ctx context.Context, opt *eclint.Option, def *editorconfig.Definition, r result) result {
+	var err error
+
+	if opt.DryRun {
+		r.diff, err = eclint.DiffWithDefinition(ctx, opt.Rules, def, r.filename)
+	} else {
+		r.report, err = eclint.FixWithDefinition(ctx, opt.Rules, def, r.filename, opt.BackupSuffix)
+	}
+
+	if err != nil {
+		log := logr.FromContextOrDiscard(ctx).WithValues("filename", r.filename)
+
+		if errors.Is(err, eclint.ErrLossyConversion) {
+			log.Error(err, "cannot convert the charset, file skipped")
+
+			return r
+		}
+
+		log.Error(err, "fixing errors failure")
+
+		r.err = err
+	}
+
+	return r
+}
+
+// checkGitAttributes adds the conflict between the eol attribute and the
+// end_of_line of the file, if any.
+func checkGitAttributes(opt *eclint.Option, def *editorconfig.Definition, j job, errs []error) []error {
+	if err := eclint.CheckGitAttributes(opt.Rules, def, j.filename, j.attrs); err != nil {
+		return append(errs, err)
+	}
+
+	return errs
+}
+
+// reportFixes hands the changes to the reporter, the formats which cannot
+// render them get them logged.
+func reportFixes(ctx context.Context, reporter eclint.Reporter, report *eclint.FixReport) error {
+	if report == nil {
+		return nil
+	}
+
+	if fr, ok := reporter.(eclint.FixReporter); ok {
+		return fr.ReportFixes(ctx, report)
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+	for rule, n := range report.Totals() {
+		log.V(1).Info("fixed", "filename", report.Filename, "rule", rule, "lines", n)
+	}
+
+	return nil
+}
+
+// processStdin lints, or fixes, the content of the reader using the
+// definition of the virtual filename.
+//
+// With -fix, the fixed content is written to the standard output, or its
+// diff with -dry-run.
+func processStdin(ctx context.Context, opt *eclint.Option, reporter eclint.Reporter, stdin io.Reader) (int, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("filename", opt.StdinFilename)
+
+	content, err := io.ReadAll(stdin)
+	if err != nil {
+		return 0, fmt.Errorf("cannot read the standard input: %w", err)
+	}
+
+	def, err := editorconfig.GetDefinitionForFilename(opt.StdinFilename)
+	if err != nil {
+		return 0, fmt.Errorf("cannot load the definition of %s. %w", opt.StdinFilename, err)
+	}
+
+	if err := eclint.OverrideDefinitionUsingPrefix(def, overridePrefix); err != nil {
+		return 0, fmt.Errorf("overriding the definition failed: %w", err)
+	}
+
+	size := int64(len(content))
+
+	if !opt.FixAllErrors {
+		errs := eclint.LintReader(ctx, opt.Rules, def, opt.StdinFilename, bytes.NewReader(content), size)
+
+		if err := reporter.Report(ctx, opt.StdinFilename, errs); err != nil {
+			return 0, err
+		}
+
+		return eclint.CountErrors(errs), nil
+	}
+
+	out, report, err := eclint.FixReader(ctx, opt.Rules, def, opt.StdinFilename, bytes.NewReader(content), size)
+	if err != nil {
+		return 0, err
+	}
+
+	fixed := content
+
+	if out != nil {
+		fixed, err = io.ReadAll(out)
+		if err != nil {
+			return 0, fmt.Errorf("cannot read the fixed content: %w", err)
+		}
+
+		for rule, n := range report.Totals() {
+			log.V(1).Info("fixed", "rule", rule, "lines", n)
+		}
+	}
+
+	if opt.DryRun {
+		if err := eclint.UnifiedDiff(opt.Stdout, filepath.ToSlash(opt.StdinFilename), content, fixed); err != nil {
+			return 0, err
+		}
+
+		if report != nil {
+			return 1, nil
+		}
+
+		return 0, nil
+	}
+
+	if _, err := opt.Stdout.Write(fixed); err != nil {
+		return 0, fmt.Errorf("cannot write the fixed content: %w", err)
+	}
+
+	return 0, nil
+}
+
+// loadBaseline reads the baseline file or creates a new one when it's
+// about to be written.
+func loadBaseline(opt *eclint.Option) (*eclint.Baseline, error) {
+	if opt.WriteBaseline != "" {
+		return eclint.NewBaseline(), nil
+	}
+
+	if opt.Baseline == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	fp, err := os.Open(opt.Baseline)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fp.Close()
+
+	return eclint.ReadBaseline(fp)
+}
+
+// saveBaseline writes the recorded baseline or logs the entries that no
+// longer match anything.
+func saveBaseline(ctx context.Context, opt *eclint.Option, baseline *eclint.Baseline) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if baseline == nil {
+		return nil
+	}
+
+	if opt.WriteBaseline == "" {
+		logUnmatched(ctx, opt.Baseline, baseline.Unmatched())
+
+		return nil
+	}
+
+	fp, err := os.Create(opt.WriteBaseline)
+	if err != nil {
+		return err
+	}
+
+	defer fp.Close()
+
+	if err := baseline.Write(fp); err != nil {
+		return err
+	}
+
+	log.V(1).Info("baseline written", "baseline", opt.WriteBaseline, "count", baseline.Len())
+
+	return fp.Close()
+}
+
+// logUnmatched lists the baseline entries that no longer match anything
+// apart from the report of the files, they don't fail the run.
+func logUnmatched(ctx context.Context, baseline string, entries []eclint.BaselineEntry) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	for _, e := range entries {
+		log.Info(
+			"baseline entry no longer matching anything, write the baseline again to drop it",
+			"baseline", baseline,
+			"filename", e.Filename,
+			"rule", e.Rule,
+			"count", e.Count,
+		)
+	}
+}
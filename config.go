@@ -0,0 +1,469 @@
+package eclint
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+)
+
+// ConfigFilename is the name of the .editorconfig files.
+const ConfigFilename = ".editorconfig"
+
+// Rule identifiers of the checks of the .editorconfig files themselves.
+const (
+	RuleConfigSyntax     = "config_syntax"
+	RuleUnknownProperty  = "unknown_property"
+	RuleInvalidValue     = "invalid_value"
+	RuleInvalidGlob      = "invalid_glob"
+	RuleDuplicateSection = "duplicate_section"
+	RuleShadowedProperty = "shadowed_property"
+	RuleMisplacedRoot    = "misplaced_root"
+)
+
+// ConfigRules lists the checks of the .editorconfig files, see CheckConfig.
+func ConfigRules() []Rule {
+	return []Rule{
+		{RuleConfigSyntax, "The .editorconfig lines are sections, properties or comments."},
+		{RuleUnknownProperty, "The .editorconfig properties are known ones."},
+		{RuleInvalidValue, "The .editorconfig properties have a valid value."},
+		{RuleInvalidGlob, "The .editorconfig sections have a valid glob."},
+		{RuleDuplicateSection, "The .editorconfig sections are not repeated."},
+		{RuleShadowedProperty, "The .editorconfig properties are not always overridden by a later one."},
+		{RuleMisplacedRoot, "The .editorconfig root property is set before the first section."},
+	}
+}
+
+const (
+	// configPrefix is the prefix of the properties only read by eclint.
+	configPrefix = "eclint_"
+	// configMaxSuggestion is the edit distance of a typo.
+	configMaxSuggestion = 2
+)
+
+// configValues lists the valid values of the properties, nil accepts any
+// value.
+//
+//nolint:gochecknoglobals
+var configValues = map[string][]string{
+	"indent_style":             {TabValue, SpaceValue},
+	"indent_size":              {TabValue, "<positive number>"},
+	"tab_width":                {"<positive number>"},
+	"end_of_line":              {"lf", "crlf", "cr"},
+	"charset":                  {Latin1, Utf8, "utf-8-bom", "utf-16be", "utf-16le"},
+	"trim_trailing_whitespace": {"true", "false"},
+	"insert_final_newline":     {"true", "false"},
+	"max_line_length":          {"off", "<number>"},
+	"spelling_language":        nil,
+	"line_comment":             nil,
+	"block_comment_start":      nil,
+	"block_comment":            nil,
+	"block_comment_end":        nil,
+}
+
+// configPair is a property of a .editorconfig file.
+type configPair struct {
+	key   string
+	value string
+	// index is the line, starting at zero.
+	index int
+	line  []byte
+}
+
+// configSection is a glob and its properties.
+type configSection struct {
+	glob  string
+	index int
+	line  []byte
+	pairs []configPair
+}
+
+// configFile is a .editorconfig file keeping track of the lines.
+type configFile struct {
+	preamble []configPair
+	sections []configSection
+}
+
+// parseConfig reads the .editorconfig file, the lines which cannot be
+// understood are returned as errors.
+func parseConfig(r io.Reader) (*configFile, []error) {
+	c := &configFile{}
+	errs := make([]error, 0)
+	scanner := bufio.NewScanner(r)
+
+	for index := 0; scanner.Scan(); index++ {
+		line := append([]byte{}, scanner.Bytes()...)
+		if index == 0 {
+			line = bytes.TrimPrefix(line, utf8Bom)
+		}
+
+		text := strings.TrimSpace(string(line))
+
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+			continue
+
+		case strings.HasPrefix(text, "["):
+			if !strings.HasSuffix(text, "]") || len(text) < 3 {
+				errs = append(errs, configError(RuleConfigSyntax, "invalid section header", index, line, text))
+
+				continue
+			}
+
+			c.sections = append(c.sections, configSection{
+				glob:  text[1 : len(text)-1],
+				index: index,
+				line:  line,
+			})
+
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok {
+				errs = append(errs, configError(RuleConfigSyntax, "a key = value pair was expected", index, line, text))
+
+				continue
+			}
+
+			value = strings.TrimSpace(value)
+			pair := configPair{
+				key:   strings.ToLower(strings.TrimSpace(key)),
+				value: value,
+				index: index,
+				line:  line,
+			}
+
+			if len(c.sections) == 0 {
+				c.preamble = append(c.preamble, pair)
+			} else {
+				s := &c.sections[len(c.sections)-1]
+				s.pairs = append(s.pairs, pair)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("cannot read the configuration: %w", err))
+	}
+
+	return c, errs
+}
+
+// configError builds the error of the line, positioned at the given text.
+func configError(rule string, message string, index int, line []byte, at string) ValidationError {
+	position := bytes.Index(line, []byte(at))
+	if position < 0 {
+		position = 0
+	}
+
+	return ValidationError{
+		Rule:     rule,
+		Severity: SeverityError,
+		Message:  message,
+		Line:     line,
+		Index:    index,
+		Position: position,
+	}
+}
+
+// configWarning builds the warning of the line, positioned at the given text.
+func configWarning(rule string, message string, index int, line []byte, at string) ValidationError {
+	ve := configError(rule, message, index, line, at)
+	ve.Severity = SeverityWarning
+
+	return ve
+}
+
+// CheckConfig validates the given .editorconfig file.
+func CheckConfig(ctx context.Context, rules *RuleSet, filename string) []error {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return []error{fmt.Errorf("cannot open %s. %w", filename, err)}
+	}
+
+	defer fp.Close()
+
+	return CheckConfigReader(ctx, rules, filename, fp)
+}
+
+// CheckConfigReader validates the content of a .editorconfig file.
+//
+// It reports the unknown properties, the invalid values and globs, the
+// duplicated sections, the properties always overridden by a later one and
+// a root property which isn't at the top of the file. The rules disabled by
+// the RuleSet, see NewConfigRuleSet, are left out.
+func CheckConfigReader(_ context.Context, rules *RuleSet, filename string, r io.Reader) []error {
+	c, errs := parseConfig(r)
+
+	for _, p := range c.preamble {
+		errs = append(errs, checkPreamblePair(p)...)
+	}
+
+	globs := make(map[string]int)
+
+	for i, s := range c.sections {
+		if err := checkGlob(s.glob); err != nil {
+			message := fmt.Sprintf("invalid glob %q: %s", s.glob, err)
+			errs = append(errs, configError(RuleInvalidGlob, message, s.index, s.line, s.glob))
+		}
+
+		if first, ok := globs[s.glob]; ok {
+			message := fmt.Sprintf("[%s] was already defined on line %d", s.glob, first+1)
+			errs = append(errs, configWarning(RuleDuplicateSection, message, s.index, s.line, s.glob))
+		} else {
+			globs[s.glob] = s.index
+		}
+
+		for j, p := range s.pairs {
+			errs = append(errs, checkPair(p)...)
+
+			if shadow := shadowingPair(c.sections, i, j); shadow != nil {
+				message := fmt.Sprintf("%s is always overridden on line %d", p.key, shadow.index+1)
+				errs = append(errs, configWarning(RuleShadowedProperty, message, p.index, p.line, p.key))
+			}
+		}
+	}
+
+	result := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		var ve ValidationError
+		if ok := errors.As(err, &ve); ok {
+			if !rules.Enabled(ve.Rule) {
+				continue
+			}
+
+			if rules.Severity(ve.Rule) == SeverityWarning {
+				ve.Severity = SeverityWarning
+			}
+
+			ve.Filename = filename
+			err = ve
+		}
+
+		result = append(result, err)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return configIndex(result[i]) < configIndex(result[j])
+	})
+
+	return result
+}
+
+// configIndex is the line of the error, used to sort them.
+func configIndex(err error) int {
+	var ve ValidationError
+	if ok := errors.As(err, &ve); ok {
+		return ve.Index
+	}
+
+	return -1
+}
+
+// checkPreamblePair validates a property found before the first section,
+// only root is expected there.
+func checkPreamblePair(p configPair) []error {
+	if p.key != "root" {
+		message := p.key + " is outside of any section, it's ignored"
+
+		return []error{configError(RuleConfigSyntax, message, p.index, p.line, p.key)}
+	}
+
+	if v := strings.ToLower(p.value); v != "true" && v != "false" {
+		message := fmt.Sprintf("root expects true or false, got %q", p.value)
+
+		return []error{configError(RuleInvalidValue, message, p.index, p.line, p.value)}
+	}
+
+	return nil
+}
+
+// checkPair validates the property of a section.
+func checkPair(p configPair) []error {
+	if p.key == "root" {
+		message := "root must be set before the first section, it's ignored here"
+
+		return []error{configError(RuleMisplacedRoot, message, p.index, p.line, p.key)}
+	}
+
+	key := strings.TrimPrefix(p.key, configPrefix)
+
+	values, ok := configValues[key]
+	if !ok {
+		if suggestion := suggestProperty(key); suggestion != "" {
+			message := fmt.Sprintf("unknown property %s, did you mean %s?", p.key, suggestion)
+
+			return []error{configError(RuleUnknownProperty, message, p.index, p.line, p.key)}
+		}
+
+		// The properties of the other tools are tolerated, not the prefixed ones.
+		if key != p.key {
+			return []error{configError(RuleUnknownProperty, "unknown property "+p.key, p.index, p.line, p.key)}
+		}
+
+		return []error{configWarning(RuleUnknownProperty, "unknown property "+p.key, p.index, p.line, p.key)}
+	}
+
+	value := strings.ToLower(p.value)
+
+	switch {
+	case values == nil:
+		return nil
+	case value != UnsetValue && !validValue(values, value):
+		message := fmt.Sprintf("%s expects one of %s, got %q", p.key, strings.Join(values, ", "), p.value)
+
+		return []error{configError(RuleInvalidValue, message, p.index, p.line, p.value)}
+	case value != p.value:
+		message := fmt.Sprintf("%s should be lowercase, got %q", p.key, p.value)
+
+		return []error{configWarning(RuleInvalidValue, message, p.index, p.line, p.value)}
+	}
+
+	return nil
+}
+
+// validValue tells whether the value is one of the valid ones.
+func validValue(values []string, value string) bool {
+	for _, v := range values {
+		switch v {
+		case "<number>", "<positive number>":
+			n, err := strconv.Atoi(value)
+			if err == nil && (n > 0 || (n == 0 && v == "<number>")) {
+				return true
+			}
+		case value:
+			return true
+		}
+	}
+
+	return false
+}
+
+// suggestProperty finds the known property looking like the given one.
+func suggestProperty(key string) string {
+	best := ""
+	distance := configMaxSuggestion + 1
+
+	for k := range configValues {
+		if d := levenshtein(key, k); d < distance || (d == distance && k < best) {
+			best = k
+			distance = d
+		}
+	}
+
+	if distance > configMaxSuggestion {
+		return ""
+	}
+
+	return best
+}
+
+// levenshtein computes the edit distance between the two strings.
+func levenshtein(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+
+			if d := previous[j] + 1; d < current[j] {
+				current[j] = d
+			}
+
+			if d := current[j-1] + 1; d < current[j] {
+				current[j] = d
+			}
+		}
+
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+// checkGlob compiles the glob of a section.
+func checkGlob(glob string) error {
+	_, err := matchGlob(glob, "/")
+
+	return err
+}
+
+// configSelector makes the glob of a section absolute the way the
+// editorconfig library does, a glob without any slash matches at any level.
+func configSelector(glob string) string {
+	switch {
+	case strings.HasPrefix(glob, "/"):
+		return glob
+	case strings.Contains(glob, "/"):
+		return "/" + glob
+	default:
+		return "/**/" + glob
+	}
+}
+
+// shadowingPair returns the later property overriding the given one for
+// every file it applies to, if any.
+func shadowingPair(sections []configSection, i int, j int) *configPair {
+	s := sections[i]
+	key := s.pairs[j].key
+
+	for k := j + 1; k < len(s.pairs); k++ {
+		if s.pairs[k].key == key {
+			return &s.pairs[k]
+		}
+	}
+
+	for _, later := range sections[i+1:] {
+		if !coversGlob(later.glob, s.glob) {
+			continue
+		}
+
+		for k := range later.pairs {
+			if later.pairs[k].key == key {
+				return &later.pairs[k]
+			}
+		}
+	}
+
+	return nil
+}
+
+// coversGlob tells whether every file matched by the inner glob is matched
+// by the outer one.
+//
+// It's only known for sure when the globs are the same or when the outer one
+// matches any file.
+func coversGlob(outer string, inner string) bool {
+	return outer == inner || outer == "*" || outer == "**"
+}
+
+// matchGlob matches the absolute name using the glob of a section.
+func matchGlob(glob string, name string) (ok bool, err error) {
+	// Some malformed patterns make the translation panic.
+	defer func() {
+		if r := recover(); r != nil {
+			ok, err = false, fmt.Errorf("%v", r) //nolint:goerr113
+		}
+	}()
+
+	return editorconfig.FnmatchCase(configSelector(glob), name) //nolint:wrapcheck
+}
@@ -0,0 +1,132 @@
+package eclint_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/greut/eclint"
+)
+
+func TestCheckConfig(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Config   []string
+		Findings []string
+	}{
+		{
+			Name: "valid",
+			Config: []string{
+				"root = true", "", "# comment",
+				"[*]", "indent_style = space", "indent_size = 2",
+				"[*.go]", "indent_style = tab",
+			},
+		},
+		{
+			Name:     "syntax",
+			Config:   []string{"indent_size = 2", "[*]", "indent_style", "[*.go"},
+			Findings: []string{"1:config_syntax:error", "3:config_syntax:error", "4:config_syntax:error"},
+		},
+		{
+			Name: "unknown properties",
+			Config: []string{
+				"[*]", "indent_stlye = tab", "quote_type = single", "eclint_indent_size = 2", "eclint_foo = 1",
+			},
+			Findings: []string{"2:unknown_property:error", "3:unknown_property:warning", "5:unknown_property:error"},
+		},
+		{
+			Name: "invalid values",
+			Config: []string{
+				"root = yes", "[*]", "end_of_line = lF", "max_line_length = eighty", "indent_size = 0",
+				"tab_width = unset", "charset = utf-8-bom", "max_line_length = off", "trim_trailing_whitespace = 1",
+			},
+			Findings: []string{
+				"1:invalid_value:error", "3:invalid_value:warning", "4:invalid_value:error",
+				"4:shadowed_property:warning", "5:invalid_value:error", "9:invalid_value:error",
+			},
+		},
+		{
+			Name:     "globs",
+			Config:   []string{"[*.[ch]", "indent_style = tab", "[*.{md]", "indent_style = tab"},
+			Findings: []string{"1:invalid_glob:error"},
+		},
+		{
+			Name:     "duplicate sections",
+			Config:   []string{"[*.go]", "indent_style = tab", "[*.md]", "[*.go]", "tab_width = 4"},
+			Findings: []string{"4:duplicate_section:warning"},
+		},
+		{
+			Name: "shadowed properties",
+			Config: []string{
+				"[Makefile]", "indent_style = tab", "indent_size = 8",
+				"[*.go]", "indent_style = tab", "indent_style = space",
+				"[lib/*.js]", "indent_size = 4",
+				"[*]", "indent_size = 2",
+				"[*.py]", "indent_style = space",
+				"[{a,*.w}]", "indent_style = tab",
+			},
+			Findings: []string{"3:shadowed_property:warning", "5:shadowed_property:warning", "8:shadowed_property:warning"},
+		},
+		{
+			Name:     "misplaced root",
+			Config:   []string{"[*]", "root = true", "indent_style = tab"},
+			Findings: []string{"2:misplaced_root:error"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			r := strings.NewReader(strings.Join(tc.Config, "\n"))
+			findings := make([]string, 0)
+
+			for _, err := range eclint.CheckConfigReader(context.TODO(), nil, ".editorconfig", r) {
+				var ve eclint.ValidationError
+				if ok := errors.As(err, &ve); !ok {
+					t.Fatalf("a validation error was expected, got %s", err)
+				}
+
+				if ve.Filename != ".editorconfig" {
+					t.Errorf("the filename was expected, got %q", ve.Filename)
+				}
+
+				findings = append(findings, fmt.Sprintf("%d:%s:%s", ve.Index+1, ve.Rule, ve.Severity))
+			}
+
+			expected := tc.Findings
+			if expected == nil {
+				expected = []string{}
+			}
+
+			if !cmp.Equal(expected, findings) {
+				t.Errorf("diff %s", cmp.Diff(expected, findings))
+			}
+		})
+	}
+}
+
+func TestCheckConfigRuleSet(t *testing.T) {
+	t.Parallel()
+
+	rules, err := eclint.NewConfigRuleSet([]string{eclint.RuleUnknownProperty}, []string{eclint.RuleInvalidValue})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := strings.NewReader("[*]\nindent_stlye = tab\nend_of_line = lr\n")
+	errs := eclint.CheckConfigReader(context.TODO(), rules, ".editorconfig", r)
+
+	if len(errs) != 1 {
+		t.Fatalf("one error was expected, got %v", errs)
+	}
+
+	if eclint.CountErrors(errs) != 0 {
+		t.Errorf("the invalid value was expected to be a warning, got %v", errs[0])
+	}
+}
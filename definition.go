@@ -24,6 +24,8 @@ type definition struct {
 	LastLine           []byte
 	LastIndex          int
 	InsideBlockComment bool
+	// rules are the enabled rules and their severity, nil enables them all.
+	rules *RuleSet
 }
 
 func newDefinition(d *editorconfig.Definition) (*definition, error) { //nolint:cyclop
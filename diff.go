@@ -0,0 +1,300 @@
+package eclint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	// diffContext is the number of unchanged lines shown around a change.
+	diffContext = 3
+	// diffMaxEdits bounds the search of the shortest edit script, past it
+	// the remaining lines are replaced as a whole.
+	diffMaxEdits = 1024
+)
+
+const (
+	diffEqual  = ' '
+	diffDelete = '-'
+	diffInsert = '+'
+)
+
+// diffOp is one line of the edit script.
+type diffOp struct {
+	kind byte
+	line []byte
+}
+
+// UnifiedDiff writes the differences between a and b using the unified
+// format, nothing is written when they are equal.
+//
+// The file names are prefixed using a/ and b/ like git does.
+func UnifiedDiff(w io.Writer, filename string, a []byte, b []byte) error {
+	if bytes.Equal(a, b) {
+		return nil
+	}
+
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	// positions of each op in a and b.
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+
+	for i, op := range ops {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+
+		if op.kind != diffInsert {
+			aPos[i+1]++
+		}
+
+		if op.kind != diffDelete {
+			bPos[i+1]++
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "--- a/%s\n+++ b/%s\n", filename, filename)
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == diffEqual {
+			i++
+
+			continue
+		}
+
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+
+		end := hunkEnd(ops, i)
+
+		stop := end + diffContext
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+
+		writeHunk(bw, ops[start:stop], aPos[start], aPos[stop], bPos[start], bPos[stop])
+
+		i = stop
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("cannot write diff: %w", err)
+	}
+
+	return nil
+}
+
+// hunkEnd finds the end of the changes starting at i, changes separated by
+// less than twice the context are kept together.
+func hunkEnd(ops []diffOp, i int) int {
+	end := i
+
+	for j := i; j < len(ops); {
+		if ops[j].kind != diffEqual {
+			j++
+			end = j
+
+			continue
+		}
+
+		k := j
+		for k < len(ops) && ops[k].kind == diffEqual {
+			k++
+		}
+
+		if k == len(ops) || k-j > 2*diffContext {
+			break
+		}
+
+		j = k
+	}
+
+	return end
+}
+
+// writeHunk writes the header and the lines of a hunk.
+func writeHunk(w *bufio.Writer, ops []diffOp, aStart, aEnd, bStart, bEnd int) {
+	fmt.Fprintf(w, "@@ -%s +%s @@\n", hunkRange(aStart, aEnd-aStart), hunkRange(bStart, bEnd-bStart))
+
+	for _, op := range ops {
+		w.WriteByte(op.kind) //nolint:errcheck
+		w.Write(op.line)     //nolint:errcheck
+
+		if !bytes.HasSuffix(op.line, []byte{lf}) {
+			w.WriteString("\n\\ No newline at end of file\n") //nolint:errcheck
+		}
+	}
+}
+
+// hunkRange formats the range, an empty one points to the line before.
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	if length == 1 {
+		return strconv.Itoa(start + 1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// splitLines splits the content while keeping the line endings.
+func splitLines(data []byte) [][]byte {
+	lines := make([][]byte, 0)
+
+	for len(data) > 0 {
+		advance, token, err := SplitLines(data, true)
+		if err != nil {
+			lines = append(lines, data)
+
+			break
+		}
+
+		lines = append(lines, token)
+		data = data[advance:]
+	}
+
+	return lines
+}
+
+// diffLines computes the edit script going from a to b.
+//
+// The common prefix and suffix are skipped before running the Myers'
+// algorithm on what remains.
+func diffLines(a [][]byte, b [][]byte) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && bytes.Equal(a[prefix], b[prefix]) {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		bytes.Equal(a[len(a)-1-suffix], b[len(b)-1-suffix]) {
+		suffix++
+	}
+
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{diffEqual, line})
+	}
+
+	ops = append(ops, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{diffEqual, line})
+	}
+
+	return ops
+}
+
+// myers finds the shortest edit script, see "An O(ND) Difference Algorithm
+// and Its Variations" by Eugene W. Myers.
+func myers(a [][]byte, b [][]byte) []diffOp { //nolint:cyclop
+	n, m := len(a), len(b)
+	limit := n + m
+
+	if limit > diffMaxEdits {
+		limit = diffMaxEdits
+	}
+
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+
+	// Round d only reads the diagonals -d-1 to d+1 of the previous one, the
+	// trace keeps that window to stay quadratic in the number of edits.
+	trace := make([][]int, 0)
+
+	found := -1
+
+	for d := 0; d <= limit && found < 0; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && bytes.Equal(a[x], b[y]) {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				found = d
+
+				break
+			}
+		}
+	}
+
+	if found < 0 {
+		// Too many changes, replace everything.
+		ops := make([]diffOp, 0, n+m)
+		for _, line := range a {
+			ops = append(ops, diffOp{diffDelete, line})
+		}
+
+		for _, line := range b {
+			ops = append(ops, diffOp{diffInsert, line})
+		}
+
+		return ops
+	}
+
+	// Backtrack from the end.
+	reversed := make([]diffOp, 0, n+m)
+	x, y := n, m
+
+	for d := found; d >= 0; d-- {
+		// The window starts at the diagonal -d-1.
+		v := trace[d]
+		shift := d + 1
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[shift+k-1] < v[shift+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := v[shift+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, diffOp{diffEqual, a[x]})
+		}
+
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, diffOp{diffInsert, b[prevY]})
+			} else {
+				reversed = append(reversed, diffOp{diffDelete, a[prevX]})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	ops := make([]diffOp, len(reversed))
+	for i, op := range reversed {
+		ops[len(reversed)-1-i] = op
+	}
+
+	return ops
+}
@@ -0,0 +1,73 @@
+package eclint_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/greut/eclint"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		Name string
+		A    string
+		B    string
+		Diff string
+	}{
+		{
+			Name: "equal",
+			A:    "a\nb\n",
+			B:    "a\nb\n",
+			Diff: "",
+		}, {
+			Name: "one line",
+			A:    "a\nb \nc\n",
+			B:    "a\nb\nc\n",
+			Diff: "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n a\n-b \n+b\n c\n",
+		}, {
+			Name: "final newline",
+			A:    "a",
+			B:    "a\n",
+			Diff: "--- a/f\n+++ b/f\n@@ -1 +1 @@\n-a\n\\ No newline at end of file\n+a\n",
+		}, {
+			Name: "from empty",
+			A:    "",
+			B:    "a\n",
+			Diff: "--- a/f\n+++ b/f\n@@ -0,0 +1 @@\n+a\n",
+		}, {
+			Name: "two hunks",
+			A:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			B:    "0\n2\n3\n4\n5\n6\n7\n8\n9\n0\n",
+			Diff: "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+0\n",
+		}, {
+			Name: "removed lines",
+			A:    "a\n\n\n",
+			B:    "a",
+			Diff: "--- a/f\n+++ b/f\n@@ -1,3 +1 @@\n-a\n-\n-\n+a\n\\ No newline at end of file\n",
+		}, {
+			Name: "interleaved",
+			A:    "a\nb\nc\nd\ne\nf\n",
+			B:    "b\nx\nc\nd\nf\ny\n",
+			Diff: "--- a/f\n+++ b/f\n@@ -1,6 +1,6 @@\n-a\n b\n+x\n c\n d\n-e\n f\n+y\n",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := bytes.NewBuffer(nil)
+			if err := eclint.UnifiedDiff(buf, "f", []byte(tc.A), []byte(tc.B)); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.Diff, buf.String()); diff != "" {
+				t.Errorf("unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package eclint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+)
+
+// ExplainedSection is a section of a .editorconfig file matching the file.
+type ExplainedSection struct {
+	Filename string
+	Glob     string
+	// Line starts at one.
+	Line int
+}
+
+// ExplainedValue is a value given to a property by a section.
+type ExplainedValue struct {
+	Value   string
+	Section ExplainedSection
+	// Line starts at one.
+	Line int
+}
+
+// ExplainedProperty is a property of the definition, the values set by the
+// sections come by increasing precedence. Without any, the value was
+// derived from the other properties.
+type ExplainedProperty struct {
+	Key    string
+	Value  string
+	Values []ExplainedValue
+}
+
+// ExplainedOverride is a property replaced by its prefixed counterpart.
+type ExplainedOverride struct {
+	Key      string
+	Value    string
+	Previous string
+}
+
+// ExplainedDefinition is the outcome of newDefinition.
+type ExplainedDefinition struct {
+	IndentSize        int
+	TabWidth          int
+	MaxLength         int
+	BlockCommentStart string
+	BlockComment      string
+	BlockCommentEnd   string
+}
+
+// Explanation tells where the definition of a file comes from.
+type Explanation struct {
+	Filename string
+	// Files are the .editorconfig files read, from the outermost one.
+	Files []string
+	// Root is the file declaring root = true, if any.
+	Root       string
+	Sections   []ExplainedSection
+	Properties []ExplainedProperty
+	Overrides  []ExplainedOverride
+	// Definition is nil when the properties are invalid, Err tells why.
+	Definition *ExplainedDefinition
+	Err        error
+}
+
+// Explain resolves the definition of the file the way the linter does,
+// keeping track of the sections setting each property.
+//
+// The properties starting with the prefix override the nominal ones, see
+// OverrideDefinitionUsingPrefix.
+func Explain(filename string, prefix string) (*Explanation, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve %s. %w", filename, err)
+	}
+
+	d, err := editorconfig.GetDefinitionForFilename(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the definition of %s. %w", filename, err)
+	}
+
+	e := &Explanation{Filename: filename}
+
+	values, err := e.trace(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range d.Raw {
+		e.Properties = append(e.Properties, ExplainedProperty{
+			Key:    key,
+			Value:  value,
+			Values: values[key],
+		})
+
+		if strings.HasPrefix(key, prefix) {
+			e.Overrides = append(e.Overrides, ExplainedOverride{
+				Key:      key[len(prefix):],
+				Value:    value,
+				Previous: d.Raw[key[len(prefix):]],
+			})
+		}
+	}
+
+	sort.Slice(e.Properties, func(i, j int) bool {
+		return e.Properties[i].Key < e.Properties[j].Key
+	})
+
+	sort.Slice(e.Overrides, func(i, j int) bool {
+		return e.Overrides[i].Key < e.Overrides[j].Key
+	})
+
+	if err := OverrideDefinitionUsingPrefix(d, prefix); err != nil {
+		e.Err = err
+
+		return e, nil
+	}
+
+	def, err := newDefinition(d)
+	if err != nil {
+		e.Err = err
+
+		return e, nil
+	}
+
+	e.Definition = &ExplainedDefinition{
+		IndentSize:        def.IndentSize,
+		TabWidth:          def.TabWidth,
+		MaxLength:         def.MaxLength,
+		BlockCommentStart: string(def.BlockCommentStart),
+		BlockComment:      string(def.BlockComment),
+		BlockCommentEnd:   string(def.BlockCommentEnd),
+	}
+
+	return e, nil
+}
+
+// trace reads the .editorconfig files applying to the file, up to the root
+// one, and collects the values set by the matching sections.
+func (e *Explanation) trace(abs string) (map[string][]ExplainedValue, error) {
+	configs := make(map[string]*configFile)
+
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		path := filepath.Join(dir, ConfigFilename)
+
+		c, err := readConfigFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if c != nil {
+			e.Files = append([]string{path}, e.Files...)
+			configs[path] = c
+
+			if c.root() {
+				e.Root = path
+
+				break
+			}
+		}
+
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	values := make(map[string][]ExplainedValue)
+
+	for _, path := range e.Files {
+		name := filepath.ToSlash(strings.TrimPrefix(abs, filepath.Dir(path)))
+		if !strings.HasPrefix(name, "/") {
+			name = "/" + name
+		}
+
+		for _, s := range configs[path].sections {
+			ok, err := matchGlob(s.glob, name)
+			if err != nil {
+				return nil, fmt.Errorf("cannot match %s using [%s] of %s. %w", name, s.glob, path, err)
+			}
+
+			if !ok {
+				continue
+			}
+
+			section := ExplainedSection{Filename: path, Glob: s.glob, Line: s.index + 1}
+			e.Sections = append(e.Sections, section)
+
+			for _, p := range s.pairs {
+				values[p.key] = append(values[p.key], ExplainedValue{
+					Value:   p.value,
+					Section: section,
+					Line:    p.index + 1,
+				})
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// readConfigFile parses the .editorconfig file, a missing file is fine. The
+// syntax errors are left to CheckConfig.
+func readConfigFile(filename string) (*configFile, error) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("cannot open %s. %w", filename, err)
+	}
+
+	defer fp.Close()
+
+	c, _ := parseConfig(fp)
+
+	return c, nil
+}
+
+// root tells whether the file stops the lookup of the parent ones.
+func (c *configFile) root() bool {
+	root := false
+
+	for _, p := range c.preamble {
+		if p.key == "root" {
+			root = strings.EqualFold(p.value, "true")
+		}
+	}
+
+	return root
+}
@@ -0,0 +1,120 @@
+package eclint_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/greut/eclint"
+)
+
+func TestExplain(t *testing.T) {
+	d := t.TempDir()
+
+	files := map[string]string{
+		".editorconfig": "root = true\n\n[*]\nindent_style = space\nindent_size = 2\nmax_line_length = 100\n" +
+			"\n[*.go]\nindent_style = tab\neclint_indent_size = 8\n",
+		"sub/.editorconfig": "[*.go]\nindent_size = 4\nblock_comment_start = /*\nblock_comment_end = */\n" +
+			"\n[main.go]\nmax_line_length = off\n\n[*.md]\nindent_size = 3\n",
+		"sub/main.go":               "",
+		"sub/.hidden/.editorconfig": "[*]\nblock_comment_start = <!--\n",
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(d, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	root := filepath.Join(d, ".editorconfig")
+	sub := filepath.Join(d, "sub", ".editorconfig")
+
+	e, err := eclint.Explain(filepath.Join(d, "sub", "main.go"), "eclint_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{root, sub}, e.Files); diff != "" {
+		t.Errorf("files mismatch (-want +got):\n%s", diff)
+	}
+
+	if e.Root != root {
+		t.Errorf("root: expected %s, got %s", root, e.Root)
+	}
+
+	sections := []eclint.ExplainedSection{
+		{Filename: root, Glob: "*", Line: 3},
+		{Filename: root, Glob: "*.go", Line: 8},
+		{Filename: sub, Glob: "*.go", Line: 1},
+		{Filename: sub, Glob: "main.go", Line: 6},
+	}
+	if diff := cmp.Diff(sections, e.Sections); diff != "" {
+		t.Errorf("sections mismatch (-want +got):\n%s", diff)
+	}
+
+	properties := make(map[string]eclint.ExplainedProperty)
+	for _, p := range e.Properties {
+		properties[p.Key] = p
+	}
+
+	indentSize := eclint.ExplainedProperty{
+		Key:   "indent_size",
+		Value: "4",
+		Values: []eclint.ExplainedValue{
+			{Value: "2", Section: sections[0], Line: 5},
+			{Value: "4", Section: sections[2], Line: 2},
+		},
+	}
+	if diff := cmp.Diff(indentSize, properties["indent_size"]); diff != "" {
+		t.Errorf("indent_size mismatch (-want +got):\n%s", diff)
+	}
+
+	if p := properties["max_line_length"]; p.Value != "off" || len(p.Values) != 2 {
+		t.Errorf("max_line_length: expected off, set twice, got %+v", p)
+	}
+
+	overrides := []eclint.ExplainedOverride{{Key: "indent_size", Value: "8", Previous: "4"}}
+	if diff := cmp.Diff(overrides, e.Overrides); diff != "" {
+		t.Errorf("overrides mismatch (-want +got):\n%s", diff)
+	}
+
+	definition := &eclint.ExplainedDefinition{
+		IndentSize:        8,
+		TabWidth:          4,
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+	}
+	if diff := cmp.Diff(definition, e.Definition); diff != "" {
+		t.Errorf("definition mismatch (-want +got):\n%s", diff)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := eclint.PrintExplanation(context.TODO(), &eclint.Option{Stdout: buf}, e); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(buf.String(), "indent_size = 8 instead of 4") {
+		t.Errorf("the override is missing from the output:\n%s", buf.String())
+	}
+
+	// A block comment without its end.
+	e, err = eclint.Explain(filepath.Join(d, "sub", ".hidden", "index.html"), "eclint_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e.Definition != nil || !errors.Is(e.Err, eclint.ErrConfiguration) {
+		t.Errorf("expected a configuration error, got %v", e.Err)
+	}
+}
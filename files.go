@@ -1,13 +1,17 @@
 package eclint
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
 
 	"github.com/go-logr/logr"
 )
@@ -31,11 +35,15 @@ func ListFilesContext(ctx context.Context, args ...string) (<-chan string, <-cha
 
 	log.V(3).Info("fallback to `git ls-files`", "dir", dir)
 
-	return GitLsFilesContext(ctx, dir)
+	return GitLsFilesContext(ctx, dir, false)
 }
 
 // WalkContext iterates on each path item recursively (asynchronously).
 //
+// The files ignored by git, using the .gitignore files, .git/info/exclude
+// and the global excludes file, are skipped as well as the .git directories.
+// The files given explicitly are always listed.
+//
 // Future work: use godirwalk.
 func WalkContext(ctx context.Context, paths ...string) (<-chan string, <-chan error) {
 	filesChan := make(chan string, 128)
@@ -48,27 +56,18 @@ func WalkContext(ctx context.Context, paths ...string) (<-chan string, <-chan er
 		for _, path := range paths {
 			// shortcircuit files
 			if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
-				filesChan <- path
-
-				break
-			}
-
-			err := fs.WalkDir(os.DirFS(path), ".", func(filename string, _ fs.DirEntry, err error) error {
-				if err != nil {
-					return err
-				}
-
 				select {
-				case filesChan <- filename:
-					return nil
+				case filesChan <- path:
+					continue
 				case <-ctx.Done():
-					return fmt.Errorf("walking dir got interrupted: %w", ctx.Err())
+					return
 				}
-			})
-			if err != nil {
+			}
+
+			if err := walkDir(ctx, path, filesChan); err != nil {
 				errChan <- err
 
-				break
+				return
 			}
 		}
 	}()
@@ -76,13 +75,78 @@ func WalkContext(ctx context.Context, paths ...string) (<-chan string, <-chan er
 	return filesChan, errChan
 }
 
+// walkDir lists the files of the directory which aren't ignored.
+func walkDir(ctx context.Context, dir string, filesChan chan<- string) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	ignore, prefix, err := newGitignore(dir)
+	if err != nil {
+		return err
+	}
+
+	err = fs.WalkDir(os.DirFS(dir), ".", func(filename string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		walked := filepath.Join(dir, filepath.FromSlash(filename))
+
+		// name is relative to the root of the repository.
+		name := prefix
+		if filename != "." {
+			name = path.Join(prefix, filename)
+		}
+
+		if d.Name() == ".git" {
+			log.V(3).Info("skipped git directory", "filename", walked)
+
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if filename != "." {
+			if p := ignore.match(name, d.IsDir()); p != nil && !p.negate {
+				log.V(3).Info("skipped ignored path", "filename", walked, "pattern", p.source)
+
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+
+				return nil
+			}
+		}
+
+		if d.IsDir() {
+			if err := ignore.load(filepath.Join(walked, ".gitignore"), name); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case filesChan <- walked:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("walking dir got interrupted: %w", ctx.Err())
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("cannot walk %s: %w", dir, err)
+	}
+
+	return nil
+}
+
 // GitLsFilesContext returns the list of file base on what is in the git index (asynchronously).
 //
 // -z is mandatory as some repositories non-ASCII file names which creates
 // quoted and escaped file names. This method also returns directories for
 // any submodule there is. Submodule will be skipped afterwards and thus
-// not checked.
-func GitLsFilesContext(ctx context.Context, path string) (<-chan string, <-chan error) {
+// not checked. With untracked, the files unknown to git are listed as well,
+// the ignored ones are left out.
+func GitLsFilesContext(ctx context.Context, path string, untracked bool) (<-chan string, <-chan error) {
 	filesChan := make(chan string, 128)
 	errChan := make(chan error, 1)
 
@@ -90,7 +154,12 @@ func GitLsFilesContext(ctx context.Context, path string) (<-chan string, <-chan
 		defer close(filesChan)
 		defer close(errChan)
 
-		output, err := exec.CommandContext(ctx, "git", "ls-files", "-z", path).Output()
+		args := []string{"ls-files", "-z"}
+		if untracked {
+			args = append(args, "--cached", "--others", "--exclude-standard")
+		}
+
+		output, err := exec.CommandContext(ctx, "git", append(args, path)...).Output() //nolint:gosec
 		if err != nil {
 			var e *exec.ExitError
 			if ok := errors.As(err, &e); ok {
@@ -120,3 +189,144 @@ func GitLsFilesContext(ctx context.Context, path string) (<-chan string, <-chan
 
 	return filesChan, errChan
 }
+
+// ReadFilesContext returns the list of files read from r (asynchronously).
+//
+// The files are separated by new lines or, when nul is set, by NUL
+// characters as produced by `find -print0`. The empty entries are skipped.
+func ReadFilesContext(ctx context.Context, r io.Reader, nul bool) (<-chan string, <-chan error) {
+	filesChan := make(chan string, 128)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(filesChan)
+		defer close(errChan)
+
+		scanner := bufio.NewScanner(r)
+		if nul {
+			scanner.Split(scanNul)
+		}
+
+		for scanner.Scan() {
+			f := scanner.Text()
+			if f == "" {
+				continue
+			}
+
+			select {
+			case filesChan <- f:
+				// everything is good
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			errChan <- fmt.Errorf("cannot read the list of files: %w", err)
+		}
+	}()
+
+	return filesChan, errChan
+}
+
+// scanNul is a bufio.SplitFunc returning the NUL separated entries.
+func scanNul(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
+
+// GitDiffFilesContext returns the list of files added, copied, modified or
+// renamed since the given ref (asynchronously).
+//
+// The changes are the ones of `git diff ref...HEAD`, i.e. since the merge
+// base, and the uncommitted ones can be added, or used alone using an empty
+// ref. Renamed files are listed using their new name and the paths which
+// don't exist anymore in the working tree are skipped.
+func GitDiffFilesContext(ctx context.Context, ref string, uncommitted bool) (<-chan string, <-chan error) {
+	filesChan := make(chan string, 128)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(filesChan)
+		defer close(errChan)
+
+		log := logr.FromContextOrDiscard(ctx)
+
+		revisions := make([]string, 0, 2)
+		if ref != "" {
+			revisions = append(revisions, ref+"...HEAD")
+		}
+
+		if uncommitted {
+			revisions = append(revisions, "HEAD")
+		}
+
+		seen := make(map[string]bool)
+
+		for _, revision := range revisions {
+			files, err := gitDiffNames(ctx, revision)
+			if err != nil {
+				errChan <- err
+
+				return
+			}
+
+			for _, f := range files {
+				if seen[f] {
+					continue
+				}
+
+				seen[f] = true
+
+				if _, err := os.Lstat(f); err != nil {
+					log.V(1).Info("skipped missing file", "filename", f, "error", err.Error())
+
+					continue
+				}
+
+				select {
+				case filesChan <- f:
+					// everything is good
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return filesChan, errChan
+}
+
+// gitDiffNames lists the files changed by the revision, or staged using
+// --cached, relatively to the current directory.
+func gitDiffNames(ctx context.Context, revision string) ([]string, error) {
+	output, err := exec.CommandContext( //nolint:gosec
+		ctx,
+		"git", "diff", "--name-only", "-z", "--relative", "--diff-filter=ACMR", revision, "--",
+	).Output()
+	if err != nil {
+		var e *exec.ExitError
+		if ok := errors.As(err, &e); ok {
+			err = fmt.Errorf("git diff %s failed with %s: %w", revision, bytes.TrimSpace(e.Stderr), e)
+		}
+
+		return nil, err
+	}
+
+	files := make([]string, 0)
+
+	for _, f := range bytes.Split(output, []byte{0}) {
+		if len(f) > 0 {
+			files = append(files, string(f))
+		}
+	}
+
+	return files, nil
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"gitlab.com/greut/eclint"
 )
 
@@ -165,13 +170,45 @@ outer:
 	}
 }
 
+func TestWalkPaths(t *testing.T) {
+	d := testdataSimple
+
+	// The walk goes on after a file and names the files from the given paths.
+	paths := []string{
+		filepath.Join(d, "simple.txt"),
+		filepath.Join(d, ".editorconfig"),
+		filepath.Join(d, "empty"),
+	}
+	expected := []string{
+		d + "/simple.txt",
+		d + "/.editorconfig",
+		d + "/empty",
+		d + "/empty/.keep",
+	}
+
+	files := make([]string, 0)
+	fsChan, errChan := eclint.WalkContext(context.TODO(), paths...)
+
+	for f := range fsChan {
+		files = append(files, filepath.ToSlash(f))
+	}
+
+	if err := <-errChan; err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(expected, files) {
+		t.Errorf("diff %s", cmp.Diff(expected, files))
+	}
+}
+
 func TestGitLsFiles(t *testing.T) {
 	skipNoGit(t)
 
 	d := testdataSimple
 
 	fs := 0
-	fsChan, errChan := eclint.GitLsFilesContext(context.TODO(), d)
+	fsChan, errChan := eclint.GitLsFilesContext(context.TODO(), d, false)
 
 outer:
 	for {
@@ -203,7 +240,7 @@ func TestGitLsFilesFailure(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, errChan := eclint.GitLsFilesContext(context.TODO(), d)
+	_, errChan := eclint.GitLsFilesContext(context.TODO(), d, false)
 
 	if err := <-errChan; err == nil {
 		t.Error("an error was expected")
@@ -217,3 +254,277 @@ func skipNoGit(t *testing.T) {
 		t.Skip("skipping test requiring .git to be present")
 	}
 }
+
+// gitRepo creates a git repository in a temporary directory, becoming the
+// current one, and returns a helper running git commands within it.
+func gitRepo(t *testing.T) func(args ...string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("skipping test requiring git")
+	}
+
+	d := t.TempDir()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(d); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		os.Chdir(cwd) //nolint:errcheck
+	})
+
+	git := func(args ...string) string {
+		t.Helper()
+
+		args = append([]string{"-c", "user.name=eclint", "-c", "user.email=eclint@example.org"}, args...)
+
+		out, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s: %s", args, err, out)
+		}
+
+		return strings.TrimSpace(string(out))
+	}
+
+	git("init", "-q")
+
+	return git
+}
+
+func TestGitDiffFiles(t *testing.T) { //nolint:paralleltest
+	git := gitRepo(t)
+
+	write := func(filename, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a.txt", "a\n")
+	write("b.txt", "b\nb\nb\nb\n")
+	write("c.txt", "c\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "base")
+	base := git("rev-parse", "HEAD")
+
+	// committed: a modified, b renamed into d, c deleted, e added.
+	write("a.txt", "aa\n")
+	git("mv", "b.txt", "d.txt")
+	git("rm", "-q", "c.txt")
+	write("e.txt", "e\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "change")
+
+	// uncommitted: a modified, f added, e deleted.
+	write("a.txt", "aaa\n")
+	write("f.txt", "f\n")
+	git("add", "f.txt")
+
+	if err := os.Remove("e.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name        string
+		Ref         string
+		Uncommitted bool
+		Files       []string
+	}{
+		{Name: "changed since", Ref: base, Files: []string{"a.txt", "d.txt"}},
+		{Name: "uncommitted", Uncommitted: true, Files: []string{"a.txt", "f.txt"}},
+		{Name: "both", Ref: base, Uncommitted: true, Files: []string{"a.txt", "d.txt", "f.txt"}},
+	}
+
+	for _, tc := range tests {
+		files := make([]string, 0)
+		fsChan, errChan := eclint.GitDiffFilesContext(context.TODO(), tc.Ref, tc.Uncommitted)
+
+		for f := range fsChan {
+			files = append(files, f)
+		}
+
+		if err := <-errChan; err != nil {
+			t.Fatalf("%s: no errors were expected, got %s", tc.Name, err)
+		}
+
+		sort.Strings(files)
+
+		if !cmp.Equal(tc.Files, files) {
+			t.Errorf("%s: diff %s", tc.Name, cmp.Diff(tc.Files, files))
+		}
+	}
+
+	_, errChan := eclint.GitDiffFilesContext(context.TODO(), "missing-ref", false)
+	if err := <-errChan; err == nil {
+		t.Error("an error was expected for an unknown ref")
+	}
+}
+
+func TestWalkGitignore(t *testing.T) { //nolint:paralleltest
+	gitRepo(t)
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	write := func(filename, content string) {
+		t.Helper()
+
+		if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(filepath.Join(home, ".config", "git", "ignore"), "*.swp\n")
+	write(filepath.Join(".git", "info", "exclude"), "local.txt\n")
+	write(".gitignore", "node_modules/\n/build\n*.log\n")
+	write("a.txt", "a\n")
+	write("a.txt.swp", "a\n")
+	write("local.txt", "l\n")
+	write("build/out.txt", "o\n")
+	write("node_modules/m/m.txt", "m\n")
+	write("src/b.txt", "b\n")
+	write("src/b.log", "b\n")
+	write("src/build/c.txt", "c\n")
+	write("src/.gitignore", "!keep.log\ngenerated/\n")
+	write("src/keep.log", "k\n")
+	write("src/generated/d.txt", "d\n")
+
+	tests := []struct {
+		Name  string
+		Paths []string
+		Files []string
+	}{
+		{
+			Name:  "root",
+			Paths: []string{"."},
+			Files: []string{
+				".", ".gitignore", "a.txt", "src", "src/.gitignore", "src/b.txt",
+				"src/build", "src/build/c.txt", "src/keep.log",
+			},
+		},
+		{
+			Name:  "subdirectory",
+			Paths: []string{"src"},
+			Files: []string{"src", "src/.gitignore", "src/b.txt", "src/build", "src/build/c.txt", "src/keep.log"},
+		},
+		{
+			Name:  "files and directories",
+			Paths: []string{"local.txt", "src/build", "a.txt"},
+			Files: []string{"a.txt", "local.txt", "src/build", "src/build/c.txt"},
+		},
+	}
+
+	for _, tc := range tests {
+		files := make([]string, 0)
+		fsChan, errChan := eclint.WalkContext(context.TODO(), tc.Paths...)
+
+		for f := range fsChan {
+			files = append(files, filepath.ToSlash(f))
+		}
+
+		if err := <-errChan; err != nil {
+			t.Fatalf("%s: no errors were expected, got %s", tc.Name, err)
+		}
+
+		sort.Strings(files)
+
+		if !cmp.Equal(tc.Files, files) {
+			t.Errorf("%s: diff %s", tc.Name, cmp.Diff(tc.Files, files))
+		}
+	}
+}
+
+func TestGitLsFilesUntracked(t *testing.T) { //nolint:paralleltest
+	git := gitRepo(t)
+
+	for filename, content := range map[string]string{
+		".gitignore": "*.log\n",
+		"a.txt":      "a\n",
+		"b.txt":      "b\n",
+		"c.log":      "c\n",
+	} {
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("add", ".gitignore", "a.txt")
+
+	tests := []struct {
+		Name      string
+		Untracked bool
+		Files     []string
+	}{
+		{Name: "tracked", Files: []string{".gitignore", "a.txt"}},
+		{Name: "untracked", Untracked: true, Files: []string{".gitignore", "a.txt", "b.txt"}},
+	}
+
+	for _, tc := range tests {
+		files := make([]string, 0)
+		fsChan, errChan := eclint.GitLsFilesContext(context.TODO(), ".", tc.Untracked)
+
+		for f := range fsChan {
+			files = append(files, f)
+		}
+
+		if err := <-errChan; err != nil {
+			t.Fatalf("%s: no errors were expected, got %s", tc.Name, err)
+		}
+
+		sort.Strings(files)
+
+		if !cmp.Equal(tc.Files, files) {
+			t.Errorf("%s: diff %s", tc.Name, cmp.Diff(tc.Files, files))
+		}
+	}
+}
+
+func TestReadFiles(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Input string
+		Nul   bool
+		Files []string
+	}{
+		{Name: "lines", Input: "a.txt\nb c.txt\n", Files: []string{"a.txt", "b c.txt"}},
+		{Name: "crlf and blank lines", Input: "a.txt\r\n\r\n\nb.txt", Files: []string{"a.txt", "b.txt"}},
+		{Name: "nul", Input: "a.txt\x00b\nc.txt\x00\x00", Nul: true, Files: []string{"a.txt", "b\nc.txt"}},
+		{Name: "empty", Input: "", Files: []string{}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			files := make([]string, 0)
+			fsChan, errChan := eclint.ReadFilesContext(context.TODO(), strings.NewReader(tc.Input), tc.Nul)
+
+			for f := range fsChan {
+				files = append(files, f)
+			}
+
+			if err := <-errChan; err != nil {
+				t.Fatalf("no errors were expected, got %s", err)
+			}
+
+			if !cmp.Equal(tc.Files, files) {
+				t.Errorf("diff %s", cmp.Diff(tc.Files, files))
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package eclint
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+)
+
+// IgnoreFilename is the file, at the root of the repository, listing the
+// paths to skip using the gitignore syntax.
+const IgnoreFilename = ".eclintignore"
+
+// FileFilter decides which of the listed files are checked.
+//
+// The exclude and include patterns are matched using the editorconfig
+// syntax, the .eclintignore file using the gitignore one.
+type FileFilter struct {
+	excludes []string
+	includes []string
+	root     string
+	ignore   *gitignore
+}
+
+// NewFileFilter validates the patterns and loads the .eclintignore file of
+// the repository containing the directory, or of the directory itself when
+// there is none.
+func NewFileFilter(dir string, excludes []string, includes []string) (*FileFilter, error) {
+	for _, pattern := range append(append([]string{}, excludes...), includes...) {
+		if _, err := editorconfig.FnmatchCase(pattern, "dummy"); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve %s. %w", dir, err)
+	}
+
+	root, _ := findGitDir(abs)
+	if root == "" {
+		root = abs
+	}
+
+	f := &FileFilter{
+		excludes: excludes,
+		includes: includes,
+		root:     root,
+		ignore:   &gitignore{},
+	}
+
+	if err := f.ignore.load(filepath.Join(root, IgnoreFilename), ""); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Skip tells whether the file is to be skipped, the reason explains the
+// decision.
+//
+// An exclude pattern always wins, then the .eclintignore file decides and,
+// when include patterns are given, the file has to match one of them.
+func (f *FileFilter) Skip(filename string) (bool, string, error) {
+	for _, pattern := range f.excludes {
+		ok, err := editorconfig.FnmatchCase(pattern, filename)
+		if err != nil {
+			return false, "", fmt.Errorf("cannot match %s: %w", filename, err)
+		}
+
+		if ok {
+			return true, fmt.Sprintf("excluded by %q", pattern), nil
+		}
+	}
+
+	reason := ""
+
+	if p := f.ignored(filename); p != nil {
+		if !p.negate {
+			return true, "ignored by " + p.source, nil
+		}
+
+		reason = "not ignored because of " + p.source
+	}
+
+	if len(f.includes) == 0 {
+		return false, reason, nil
+	}
+
+	for _, pattern := range f.includes {
+		ok, err := editorconfig.FnmatchCase(pattern, filename)
+		if err != nil {
+			return false, "", fmt.Errorf("cannot match %s: %w", filename, err)
+		}
+
+		if ok {
+			return false, fmt.Sprintf("included by %q", pattern), nil
+		}
+	}
+
+	return true, "not included by any pattern", nil
+}
+
+// ignored returns the .eclintignore pattern deciding the fate of the file,
+// an ignored parent directory cannot be overridden.
+func (f *FileFilter) ignored(filename string) *gitignorePattern {
+	if len(f.ignore.patterns) == 0 {
+		return nil
+	}
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil
+	}
+
+	rel, err := filepath.Rel(f.root, abs)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+
+	name := filepath.ToSlash(rel)
+	dir := ""
+
+	for _, s := range strings.Split(path.Dir(name), "/") {
+		if s == "." {
+			break
+		}
+
+		dir = path.Join(dir, s)
+
+		if p := f.ignore.match(dir, true); p != nil && !p.negate {
+			return p
+		}
+	}
+
+	return f.ignore.match(name, false)
+}
@@ -0,0 +1,74 @@
+package eclint_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+func TestFileFilter(t *testing.T) { //nolint:paralleltest
+	gitRepo(t)
+
+	ignore := "vendor/\n*.min.js\n!keep.min.js\n/generated\n"
+	if err := os.WriteFile(eclint.IgnoreFilename, []byte(ignore), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Mkdir("src", 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		Name     string
+		Excludes []string
+		Includes []string
+		Filename string
+		Skip     bool
+		Reason   string
+	}{
+		{Name: "kept", Filename: "src/a.js"},
+		{Name: "ignored", Filename: "src/a.min.js", Skip: true, Reason: ".eclintignore:2"},
+		{Name: "negated", Filename: "src/keep.min.js", Reason: ".eclintignore:3"},
+		{Name: "ignored directory", Filename: "vendor/keep.min.js", Skip: true, Reason: ".eclintignore:1"},
+		{Name: "anchored", Filename: "./generated/a.go", Skip: true, Reason: ".eclintignore:4"},
+		{Name: "not anchored", Filename: "src/generated/a.go"},
+		{Name: "outside", Filename: "../a.min.js"},
+		{
+			Name:     "excluded",
+			Excludes: []string{"**/*.md", "src/**"},
+			Filename: "src/keep.min.js",
+			Skip:     true,
+			Reason:   `"src/**"`,
+		},
+		{Name: "included", Includes: []string{"**/*.md", "src/**"}, Filename: "src/a.js", Reason: `"src/**"`},
+		{Name: "not included", Includes: []string{"**/*.md"}, Filename: "src/a.js", Skip: true, Reason: "not included"},
+		{Name: "ignored and included", Includes: []string{"**/*.js"}, Filename: "src/a.min.js", Skip: true},
+	}
+
+	for _, tc := range tests {
+		f, err := eclint.NewFileFilter("src", tc.Excludes, tc.Includes)
+		if err != nil {
+			t.Fatalf("%s: no errors were expected, got %s", tc.Name, err)
+		}
+
+		skip, reason, err := f.Skip(filepath.FromSlash(tc.Filename))
+		if err != nil {
+			t.Fatalf("%s: no errors were expected, got %s", tc.Name, err)
+		}
+
+		if skip != tc.Skip {
+			t.Errorf("%s: expected skip to be %v, got %v (%s)", tc.Name, tc.Skip, skip, reason)
+		}
+
+		if !strings.Contains(reason, tc.Reason) {
+			t.Errorf("%s: expected the reason to contain %q, got %q", tc.Name, tc.Reason, reason)
+		}
+	}
+
+	if _, err := eclint.NewFileFilter(".", []string{"[a"}, nil); err == nil {
+		t.Error("an error was expected for an invalid pattern")
+	}
+}
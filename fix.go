@@ -4,24 +4,39 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/editorconfig/editorconfig-core-go/v2"
 	"github.com/go-logr/logr"
 )
 
 // FixWithDefinition does the hard work of validating the given file.
-func FixWithDefinition(ctx context.Context, d *editorconfig.Definition, filename string) error {
+//
+// The rules tell which fixes are applied, nil enables them all. The file is
+// replaced atomically, a copy of the original is kept using the backup
+// suffix unless it's empty. The report lists the changes, it's nil when
+// nothing was fixed.
+func FixWithDefinition(
+	ctx context.Context,
+	rules *RuleSet,
+	d *editorconfig.Definition,
+	filename string,
+	backupSuffix string,
+) (*FixReport, error) {
 	def, err := newDefinition(d)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	def.rules = rules
+
 	stat, err := os.Stat(filename)
 	if err != nil {
-		return fmt.Errorf("cannot stat %s. %w", filename, err)
+		return nil, fmt.Errorf("cannot stat %s. %w", filename, err)
 	}
 
 	log := logr.FromContextOrDiscard(ctx)
@@ -29,48 +44,91 @@ func FixWithDefinition(ctx context.Context, d *editorconfig.Definition, filename
 	if stat.IsDir() {
 		log.V(2).Info("skipped directory")
 
-		return nil
+		return nil, nil
 	}
 
-	fileSize := stat.Size()
-	mode := stat.Mode()
-
-	r, fixed, err := fixWithFilename(ctx, def, filename, fileSize)
+	r, changes, err := fixWithFilename(ctx, def, filename, stat.Size())
 	if err != nil {
-		return fmt.Errorf("cannot fix %s: %w", filename, err)
+		return nil, fmt.Errorf("cannot fix %s: %w", filename, err)
 	}
 
-	if !fixed {
+	if len(changes) == 0 || r == nil {
 		log.V(1).Info("no fixes to apply", "filename", filename)
 
-		return nil
+		return nil, nil
 	}
 
-	if r == nil {
-		return nil
+	n, err := writeFile(ctx, filename, r, backupSuffix)
+	if err != nil {
+		return nil, err
 	}
 
-	// XXX keep mode as is.
-	fp, err := os.OpenFile(filename, os.O_WRONLY|os.O_TRUNC, mode) //nolint:nosnakecase
+	log.V(1).Info("bytes written", "filename", filename, "total", n)
+
+	return &FixReport{Filename: filename, Changes: changes}, nil
+}
+
+// DiffWithDefinition fixes the given file in memory and returns the
+// unified diff of the changes, it's empty when nothing would be fixed.
+func DiffWithDefinition(
+	ctx context.Context,
+	rules *RuleSet,
+	d *editorconfig.Definition,
+	filename string,
+) ([]byte, error) {
+	def, err := newDefinition(d)
 	if err != nil {
-		return fmt.Errorf("cannot open %s using %s: %w", filename, mode, err)
+		return nil, err
 	}
-	defer fp.Close()
 
-	n, err := io.Copy(fp, r)
+	def.rules = rules
+
+	stat, err := os.Stat(filename)
 	if err != nil {
-		return fmt.Errorf("error copying file: %w", err)
+		return nil, fmt.Errorf("cannot stat %s. %w", filename, err)
 	}
 
-	log.V(1).Info("bytes written", "filename", filename, "total", n)
+	log := logr.FromContextOrDiscard(ctx)
+
+	if stat.IsDir() {
+		log.V(2).Info("skipped directory")
+
+		return nil, nil
+	}
 
-	return nil
+	r, changes, err := fixWithFilename(ctx, def, filename, stat.Size())
+	if err != nil {
+		return nil, fmt.Errorf("cannot fix %s: %w", filename, err)
+	}
+
+	if len(changes) == 0 || r == nil {
+		log.V(1).Info("no fixes to apply", "filename", filename)
+
+		return nil, nil
+	}
+
+	original, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s. %w", filename, err)
+	}
+
+	result, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the fixed content: %w", err)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := UnifiedDiff(buf, filepath.ToSlash(filename), original, result); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
 }
 
-func fixWithFilename(ctx context.Context, def *definition, filename string, fileSize int64) (io.Reader, bool, error) {
+func fixWithFilename(ctx context.Context, def *definition, filename string, fileSize int64) (io.Reader, []FixChange, error) {
 	fp, err := os.Open(filename)
 	if err != nil {
-		return nil, false, fmt.Errorf("cannot open %s. %w", filename, err)
+		return nil, nil, fmt.Errorf("cannot open %s. %w", filename, err)
 	}
 
 	defer fp.Close()
@@ -79,7 +137,7 @@ func fixWithFilename(ctx context.Context, def *definition, filename string, file
 
 	ok, err := probeReadable(fp, r)
 	if err != nil {
-		return nil, false, fmt.Errorf("cannot read %s. %w", filename, err)
+		return nil, nil, fmt.Errorf("cannot read %s. %w", filename, err)
 	}
 
 	log := logr.FromContextOrDiscard(ctx)
@@ -87,18 +145,66 @@ func fixWithFilename(ctx context.Context, def *definition, filename string, file
 	if !ok {
 		log.V(2).Info("skipped unreadable or empty file")
 
-		return nil, false, nil
+		return nil, nil, nil
+	}
+
+	return fixReader(ctx, def, filename, r, fileSize)
+}
+
+// FixReader fixes the content read from r as if it was the given file.
+//
+// The fixed content is returned along with the report of the changes, both
+// are nil when there is nothing to fix.
+func FixReader(
+	ctx context.Context,
+	rules *RuleSet,
+	d *editorconfig.Definition,
+	filename string,
+	r io.Reader,
+	size int64,
+) (io.Reader, *FixReport, error) {
+	def, err := newDefinition(d)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	def.rules = rules
+
+	out, changes, err := fixReader(ctx, def, filename, bufio.NewReader(r), size)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot fix %s: %w", filename, err)
+	}
+
+	if len(changes) == 0 || out == nil {
+		return nil, nil, nil
+	}
+
+	return out, &FixReport{Filename: filename, Changes: changes}, nil
+}
+
+// fixReader fixes the content of the reader.
+func fixReader(
+	ctx context.Context,
+	def *definition,
+	filename string,
+	r *bufio.Reader,
+	fileSize int64,
+) (io.Reader, []FixChange, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if isTranscodable(def.Charset) && def.rules.Enabled(RuleCharset) {
+		return fixWithCharset(ctx, r, filename, def)
 	}
 
 	charset, isBinary, err := ProbeCharsetOrBinary(ctx, r, def.Charset)
 	if err != nil {
-		return nil, false, err
+		return nil, nil, err
 	}
 
 	if isBinary {
 		log.V(2).Info("binary file detected and skipped")
 
-		return nil, false, nil
+		return nil, nil, nil
 	}
 
 	log.V(2).Info("charset probed", "charset", charset)
@@ -106,51 +212,104 @@ func fixWithFilename(ctx context.Context, def *definition, filename string, file
 	return fix(ctx, r, fileSize, charset, def)
 }
 
+// fixWithCharset fixes the content decoded as UTF-8 and encodes the result
+// using the expected charset.
+func fixWithCharset(ctx context.Context, r *bufio.Reader, filename string, def *definition) (io.Reader, []FixChange, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	bs, err := r.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("cannot peek into reader: %w", err)
+	}
+
+	// Content which isn't UTF-8 may still be latin1 text to be transcoded.
+	if probeMagic(ctx, bs) || (detectCharsetUsingBOM(bs) == "" && probeBinary(ctx, bs) && !probeLatin1(bs)) {
+		log.V(2).Info("binary file detected and skipped")
+
+		return nil, nil, nil
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read %s. %w", filename, err)
+	}
+
+	source, err := detectSourceCharset(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.V(2).Info("charset probed", "charset", source)
+
+	text, err := decodeCharset(data, source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out, changes, err := fix(ctx, bytes.NewReader(text), int64(len(text)), Utf8, def)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fixed, err := io.ReadAll(out)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read the fixed content: %w", err)
+	}
+
+	result, err := encodeCharset(fixed, def.Charset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if source != def.Charset {
+		if converted, err := encodeCharset(text, def.Charset); err == nil && !bytes.Equal(converted, data) {
+			log.V(1).Info("transcoded", "filename", filename, "from", source, "to", def.Charset)
+
+			// The whole file is concerned.
+			changes = append([]FixChange{{
+				Rule:  RuleCharset,
+				Start: 0,
+				End:   len(splitLines(fixed)) - 1,
+			}}, changes...)
+		}
+	}
+
+	return bytes.NewReader(result), changes, nil
+}
+
 func fix( //nolint:funlen,cyclop
 	ctx context.Context,
 	r io.Reader,
 	fileSize int64,
 	_ string,
 	def *definition,
-) (io.Reader, bool, error) {
+) (io.Reader, []FixChange, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
 	buf := bytes.NewBuffer([]byte{})
 
-	size := def.IndentSize
-	if def.TabWidth != 0 {
-		size = def.TabWidth
-	}
+	rules := def.rules
 
-	if size == 0 {
-		// Indent size default == 2
-		size = 2
-	}
+	var reindent *reindenter
 
-	var c []byte
+	if rules.Enabled(RuleIndentStyle) || rules.Enabled(RuleIndentSize) {
+		// The whole content is needed to find how it's currently indented.
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read the content: %w", err)
+		}
 
-	var x []byte
+		reindent, err = newReindenter(def, splitLines(content))
+		if err != nil {
+			return nil, nil, err
+		}
 
-	switch def.IndentStyle {
-	case SpaceValue:
-		c = bytes.Repeat([]byte{space}, size)
-		x = []byte{tab}
-	case TabValue:
-		c = []byte{tab}
-		x = bytes.Repeat([]byte{space}, size)
-	case "", UnsetValue:
-		size = 0
-	default:
-		return nil, false, fmt.Errorf(
-			"%w: %q is an invalid value of indent_style, want tab or space",
-			ErrConfiguration,
-			def.IndentStyle,
-		)
+		r = bytes.NewReader(content)
 	}
 
 	eol, err := def.EOL()
 	if err != nil {
-		return nil, false, fmt.Errorf("cannot get EOL: %w", err)
+		return nil, nil, fmt.Errorf("cannot get EOL: %w", err)
 	}
 
 	trimTrailingWhitespace := false
@@ -158,22 +317,27 @@ func fix( //nolint:funlen,cyclop
 		trimTrailingWhitespace = *def.TrimTrailingWhitespace
 	}
 
-	fixed := false
+	changes := fixChanges{}
+	last := 0
 	errs := ReadLines(r, fileSize, func(index int, data []byte, isEOF bool) error {
 		var f bool
-		if size != 0 {
-			data, f = fixTabAndSpacePrefix(data, c, x)
-			fixed = fixed || f
+		if reindent != nil {
+			var rule string
+			if data, rule = reindent.fix(data); rule != "" {
+				changes.add(rule, index)
+			}
 		}
 
-		if trimTrailingWhitespace {
-			data, f = fixTrailingWhitespace(data)
-			fixed = fixed || f
+		if trimTrailingWhitespace && rules.Enabled(RuleTrimTrailingWhitespace) {
+			if data, f = fixTrailingWhitespace(data); f {
+				changes.add(RuleTrimTrailingWhitespace, index)
+			}
 		}
 
-		if def.EndOfLine != "" && !isEOF {
-			data, f = fixEndOfLine(data, eol)
-			fixed = fixed || f
+		if def.EndOfLine != "" && !isEOF && rules.Enabled(RuleEndOfLine) {
+			if data, f = fixEndOfLine(data, eol); f {
+				changes.add(RuleEndOfLine, index)
+			}
 		}
 
 		_, err := buf.Write(data)
@@ -181,21 +345,24 @@ func fix( //nolint:funlen,cyclop
 			return fmt.Errorf("error writing into buffer: %w", err)
 		}
 
-		log.V(2).Info("fix line", "index", index, "fixed", fixed)
+		log.V(2).Info("fix line", "index", index, "changes", len(changes.changes))
+
+		last = index
 
 		return nil
 	})
 
 	if len(errs) != 0 {
-		return nil, false, errs[0]
+		return nil, nil, errs[0]
 	}
 
-	if def.InsertFinalNewline != nil {
-		f := fixInsertFinalNewline(buf, *def.InsertFinalNewline, eol)
-		fixed = fixed || f
+	if def.InsertFinalNewline != nil && rules.Enabled(RuleInsertFinalNewline) {
+		if fixInsertFinalNewline(buf, *def.InsertFinalNewline, eol) {
+			changes.add(RuleInsertFinalNewline, last)
+		}
 	}
 
-	return buf, fixed, nil
+	return buf, changes.list(), nil
 }
 
 // fixEndOfLine replaces any non eol suffix by the given one.
@@ -211,38 +378,6 @@ func fixEndOfLine(data []byte, eol []byte) ([]byte, bool) {
 	return data, fixed
 }
 
-// fixTabAndSpacePrefix replaces any `x` by `c` in the given `data`.
-func fixTabAndSpacePrefix(data []byte, c []byte, x []byte) ([]byte, bool) {
-	newData := make([]byte, 0, len(data))
-
-	fixed := false
-
-	i := 0
-	for i < len(data) {
-		if bytes.HasPrefix(data[i:], c) {
-			i += len(c)
-
-			newData = append(newData, c...)
-
-			continue
-		}
-
-		if bytes.HasPrefix(data[i:], x) {
-			i += len(x)
-
-			newData = append(newData, c...)
-
-			fixed = true
-
-			continue
-		}
-
-		return append(newData, data[i:]...), fixed
-	}
-
-	return data, fixed
-}
-
 // fixTrailingWhitespace replaces any whitespace or tab from the end of the line.
 func fixTrailingWhitespace(data []byte) ([]byte, bool) {
 	i := len(data) - 1
@@ -0,0 +1,74 @@
+package eclint
+
+import (
+	"context"
+	"sort"
+)
+
+// FixChange is a change made by a rule to a range of lines.
+//
+// Start and End are the indexes of the first and last lines, starting at
+// zero like the ValidationError's Index.
+type FixChange struct {
+	Rule  string
+	Start int
+	End   int
+}
+
+// FixReport lists the changes made to a file.
+type FixReport struct {
+	Filename string
+	Changes  []FixChange
+}
+
+// Totals counts the changed lines per rule.
+func (r *FixReport) Totals() map[string]int {
+	totals := make(map[string]int)
+
+	for _, c := range r.Changes {
+		totals[c.Rule] += c.End - c.Start + 1
+	}
+
+	return totals
+}
+
+// FixReporter is implemented by the reporters able to render the fixes.
+//
+// ReportFixes is called once per fixed file, in the order they were listed.
+type FixReporter interface {
+	ReportFixes(ctx context.Context, report *FixReport) error
+}
+
+// fixChanges collects the changes while fixing the lines, the consecutive
+// lines changed by a rule are merged into one range.
+type fixChanges struct {
+	changes []FixChange
+	last    map[string]int
+}
+
+// add records the change of the line by the rule.
+func (c *fixChanges) add(rule string, index int) {
+	if c.last == nil {
+		c.last = make(map[string]int)
+	}
+
+	if i, ok := c.last[rule]; ok && c.changes[i].End+1 >= index {
+		c.changes[i].End = index
+
+		return
+	}
+
+	c.last[rule] = len(c.changes)
+	c.changes = append(c.changes, FixChange{Rule: rule, Start: index, End: index})
+}
+
+// list returns the changes sorted by line.
+func (c *fixChanges) list() []FixChange {
+	changes := append([]FixChange{}, c.changes...)
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Start < changes[j].Start
+	})
+
+	return changes
+}
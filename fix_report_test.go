@@ -0,0 +1,75 @@
+package eclint
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFixChanges(t *testing.T) {
+	c := fixChanges{}
+	c.add(RuleIndentStyle, 1)
+	c.add(RuleTrimTrailingWhitespace, 1)
+	c.add(RuleIndentStyle, 2)
+	c.add(RuleIndentStyle, 4)
+	c.add(RuleEndOfLine, 0)
+
+	expected := []FixChange{
+		{Rule: RuleEndOfLine, Start: 0, End: 0},
+		{Rule: RuleIndentStyle, Start: 1, End: 2},
+		{Rule: RuleTrimTrailingWhitespace, Start: 1, End: 1},
+		{Rule: RuleIndentStyle, Start: 4, End: 4},
+	}
+
+	changes := c.list()
+	if !cmp.Equal(expected, changes) {
+		t.Errorf("diff %s", cmp.Diff(expected, changes))
+	}
+
+	report := &FixReport{Changes: changes}
+	totals := map[string]int{
+		RuleEndOfLine:              1,
+		RuleIndentStyle:            3,
+		RuleTrimTrailingWhitespace: 1,
+	}
+
+	if !cmp.Equal(totals, report.Totals()) {
+		t.Errorf("diff %s", cmp.Diff(totals, report.Totals()))
+	}
+}
+
+func TestFixReport(t *testing.T) {
+	trim := true
+	final := true
+
+	def, err := newDefinition(&editorconfig.Definition{
+		EndOfLine:              editorconfig.EndOfLineLf,
+		IndentStyle:            "space",
+		IndentSize:             "2",
+		TrimTrailingWhitespace: &trim,
+		InsertFinalNewline:     &final,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := []byte("a\n\tb \n\tc\nd")
+
+	_, changes, err := fix(context.TODO(), bytes.NewReader(file), int64(len(file)), Utf8, def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []FixChange{
+		{Rule: RuleIndentStyle, Start: 1, End: 2},
+		{Rule: RuleTrimTrailingWhitespace, Start: 1, End: 1},
+		{Rule: RuleInsertFinalNewline, Start: 3, End: 3},
+	}
+
+	if !cmp.Equal(expected, changes) {
+		t.Errorf("diff %s", cmp.Diff(expected, changes))
+	}
+}
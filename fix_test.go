@@ -53,12 +53,12 @@ func TestFixEndOfLine(t *testing.T) { //nolint:gocognit
 			}
 
 			r := bytes.NewReader(file)
-			out, fixed, err := fix(ctx, r, fileSize, "utf-8", def)
+			out, changes, err := fix(ctx, r, fileSize, "utf-8", def)
 			if err != nil {
 				t.Fatalf("no errors where expected, got %s", err)
 			}
 
-			if fixed {
+			if len(changes) != 0 {
 				t.Errorf("file should not have been fixed")
 			}
 
@@ -84,12 +84,12 @@ func TestFixEndOfLine(t *testing.T) { //nolint:gocognit
 			}
 
 			r := bytes.NewReader(file)
-			out, fixed, err := fix(ctx, r, fileSize, "utf-8", def)
+			out, changes, err := fix(ctx, r, fileSize, "utf-8", def)
 			if err != nil {
 				t.Fatalf("no errors where expected, got %s", err)
 			}
 
-			if !fixed {
+			if len(changes) == 0 {
 				t.Errorf("file should have been fixed")
 			}
 
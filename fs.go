@@ -0,0 +1,122 @@
+package eclint
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+	"github.com/go-logr/logr"
+)
+
+// LintFS validates the given file of the filesystem.
+//
+// The .editorconfig files are looked for within the filesystem as well,
+// from the directory of the file up to its root. Every rule is enabled as an
+// error.
+func LintFS(ctx context.Context, fsys fs.FS, name string) []error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	def, err := DefinitionFS(fsys, name)
+	if err != nil {
+		return []error{err}
+	}
+
+	stat, err := fs.Stat(fsys, name)
+	if err != nil {
+		return []error{fmt.Errorf("cannot stat %s. %w", name, err)}
+	}
+
+	if stat.IsDir() {
+		log.V(2).Info("skipped directory")
+
+		return nil
+	}
+
+	fp, err := fsys.Open(name)
+	if err != nil {
+		return []error{fmt.Errorf("cannot open %s. %w", name, err)}
+	}
+
+	defer fp.Close()
+
+	return LintReader(ctx, nil, def, name, fp, stat.Size())
+}
+
+// DefinitionFS resolves the definition of the given file using the
+// .editorconfig files of the filesystem.
+func DefinitionFS(fsys fs.FS, name string) (*editorconfig.Definition, error) {
+	if !fs.ValidPath(name) {
+		return nil, fmt.Errorf("cannot load the definition of %s. %w", name, fs.ErrInvalid)
+	}
+
+	config := &editorconfig.Config{
+		Parser: &fsParser{fsys: fsys},
+	}
+
+	// The absolute path stops the lookup at the root of the filesystem.
+	def, err := config.Load(filepath.FromSlash(path.Join("/", name)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the definition of %s. %w", name, err)
+	}
+
+	return def, nil
+}
+
+// fsParser reads the .editorconfig files from a filesystem.
+type fsParser struct {
+	fsys fs.FS
+}
+
+// ParseIni parses the .editorconfig file.
+func (p *fsParser) ParseIni(filename string) (*editorconfig.Editorconfig, error) {
+	ec, warning, err := p.ParseIniGraceful(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return ec, warning
+}
+
+// ParseIniGraceful parses the .editorconfig file, keeping the warnings apart.
+func (p *fsParser) ParseIniGraceful(filename string) (*editorconfig.Editorconfig, error, error) { //nolint:stylecheck
+	fp, err := p.fsys.Open(fsName(filename))
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	defer fp.Close()
+
+	ec, warning, err := editorconfig.ParseGraceful(fp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot parse %s: %w", filename, err)
+	}
+
+	return ec, warning, nil
+}
+
+// FnmatchCase matches the filename against the glob pattern.
+func (p *fsParser) FnmatchCase(pattern string, filename string) (bool, error) {
+	ok, err := editorconfig.FnmatchCase(pattern, filename)
+	if err != nil {
+		return false, fmt.Errorf("cannot match %s: %w", filename, err)
+	}
+
+	return ok, nil
+}
+
+// fsName converts the absolute path built by the editorconfig.Config back
+// into a name of the filesystem.
+func fsName(filename string) string {
+	filename = strings.TrimPrefix(filename, filepath.VolumeName(filename))
+	name := strings.TrimPrefix(filepath.ToSlash(filename), "/")
+
+	if name == "" {
+		return "."
+	}
+
+	return name
+}
@@ -0,0 +1,66 @@
+package eclint_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"gitlab.com/greut/eclint"
+)
+
+func TestLintFS(t *testing.T) {
+	ctx := context.TODO()
+	fsys := fstest.MapFS{
+		".editorconfig": &fstest.MapFile{
+			Data: []byte(
+				"root = true\n\n[*]\ntrim_trailing_whitespace = true\n\n[*.md]\ntrim_trailing_whitespace = false\n",
+			),
+		},
+		"a.txt":     &fstest.MapFile{Data: []byte("hello \n")},
+		"b.md":      &fstest.MapFile{Data: []byte("hello  \n")},
+		"sub/c.txt": &fstest.MapFile{Data: []byte("hello\n\tworld\n")},
+		"sub/d.md":  &fstest.MapFile{Data: []byte("hello\n")},
+		"sub/.editorconfig": &fstest.MapFile{
+			Data: []byte("[*.txt]\nindent_style = space\nindent_size = 2\n"),
+		},
+	}
+
+	tests := []struct {
+		Name  string
+		Rules []string
+	}{
+		{Name: "a.txt", Rules: []string{eclint.RuleTrimTrailingWhitespace}},
+		{Name: "b.md", Rules: nil},
+		{Name: "sub/c.txt", Rules: []string{eclint.RuleIndentStyle}},
+		{Name: "sub/d.md", Rules: nil},
+		{Name: "sub", Rules: nil},
+	}
+
+	for _, tc := range tests {
+		errs := eclint.LintFS(ctx, fsys, tc.Name)
+		if len(errs) != len(tc.Rules) {
+			t.Errorf("%s: %d errors were expected, got %v", tc.Name, len(tc.Rules), errs)
+
+			continue
+		}
+
+		for i, err := range errs {
+			var ve eclint.ValidationError
+			if ok := errors.As(err, &ve); !ok || ve.Rule != tc.Rules[i] || ve.Filename != tc.Name {
+				t.Errorf("%s: a %s error was expected, got %v", tc.Name, tc.Rules[i], err)
+			}
+		}
+	}
+}
+
+func TestLintFSInvalid(t *testing.T) {
+	ctx := context.TODO()
+	fsys := fstest.MapFS{}
+
+	for _, name := range []string{"../a.txt", "missing.txt"} {
+		if errs := eclint.LintFS(ctx, fsys, name); len(errs) != 1 {
+			t.Errorf("%s: one error was expected, got %v", name, errs)
+		}
+	}
+}
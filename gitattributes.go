@@ -0,0 +1,291 @@
+package eclint
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+)
+
+// attributesFilename is the name of the git attributes files.
+const attributesFilename = ".gitattributes"
+
+// Attributes are the git attributes of a file eclint cares about.
+type Attributes struct {
+	// Binary is set when the text attribute is unset, e.g. using binary.
+	Binary bool
+	// Generated and Vendored are the linguist ones.
+	Generated bool
+	Vendored  bool
+	// EOL is the line ending, lf or crlf, used in the working tree.
+	EOL string
+	// Sources are the file and line which last set each attribute.
+	Sources map[string]string
+}
+
+// Skip tells whether the file isn't to be checked, and why.
+func (a Attributes) Skip() (bool, string) {
+	switch {
+	case a.Binary:
+		return true, "binary according to " + a.Sources["text"]
+	case a.Generated:
+		return true, "generated according to " + a.Sources["linguist-generated"]
+	case a.Vendored:
+		return true, "vendored according to " + a.Sources["linguist-vendored"]
+	}
+
+	return false, ""
+}
+
+// attributeRule is a line of a .gitattributes file.
+type attributeRule struct {
+	pattern gitignorePattern
+	// attrs are the attributes set, "-name" unsets and "!name" forgets them.
+	attrs []string
+}
+
+// GitAttributes reads the .gitattributes files of a repository without
+// requiring git.
+//
+// The global attributes file comes first, then the .gitattributes files
+// from the root down to the directory of the file, and finally
+// .git/info/attributes, the last one setting an attribute wins.
+type GitAttributes struct {
+	root   string
+	global []attributeRule
+	info   []attributeRule
+	macros map[string][]string
+	mu     sync.Mutex
+	dirs   map[string][]attributeRule
+}
+
+// NewGitAttributes loads the attributes of the repository containing the
+// directory, or of the directory itself when there is none.
+func NewGitAttributes(dir string) (*GitAttributes, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve %s. %w", dir, err)
+	}
+
+	g := &GitAttributes{
+		root: abs,
+		// binary is the only built-in macro.
+		macros: map[string][]string{"binary": {"-diff", "-merge", "-text"}},
+		dirs:   make(map[string][]attributeRule),
+	}
+
+	root, gitDir := findGitDir(abs)
+	if root != "" {
+		g.root = root
+	}
+
+	g.global, err = g.load(globalGitFile(gitDir, "attributesfile", "attributes"), "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	if gitDir != "" {
+		g.info, err = g.load(filepath.Join(gitDir, "info", "attributes"), "", true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The root file is the one defining the macros.
+	g.dirs[""], err = g.load(filepath.Join(g.root, attributesFilename), "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Get resolves the attributes of the file.
+func (g *GitAttributes) Get(filename string) (Attributes, error) {
+	a := Attributes{Sources: make(map[string]string)}
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return a, fmt.Errorf("cannot resolve %s. %w", filename, err)
+	}
+
+	rel, err := filepath.Rel(g.root, abs)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return a, nil //nolint:nilerr
+	}
+
+	name := filepath.ToSlash(rel)
+
+	rules, err := g.rules(path.Dir(name))
+	if err != nil {
+		return a, err
+	}
+
+	values := make(map[string]string)
+
+	for _, r := range rules {
+		if !r.pattern.matches(name, false) {
+			continue
+		}
+
+		for _, attr := range r.attrs {
+			g.apply(values, a.Sources, attr, r.pattern.source)
+		}
+	}
+
+	a.Binary = values["text"] == "false"
+	a.Generated = values["linguist-generated"] == "true"
+	a.Vendored = values["linguist-vendored"] == "true"
+
+	if eol := values["eol"]; eol == editorconfig.EndOfLineLf || eol == editorconfig.EndOfLineCrLf {
+		a.EOL = eol
+	}
+
+	return a, nil
+}
+
+// apply sets the attribute, the macros set their own attributes too.
+func (g *GitAttributes) apply(values map[string]string, sources map[string]string, attr string, source string) {
+	name, value := attr, "true"
+
+	switch {
+	case strings.HasPrefix(attr, "-"):
+		name, value = attr[1:], "false"
+	case strings.HasPrefix(attr, "!"):
+		name, value = attr[1:], ""
+	case strings.Contains(attr, "="):
+		name, value, _ = strings.Cut(attr, "=")
+	}
+
+	values[name] = value
+	sources[name] = source
+
+	if macro, ok := g.macros[name]; ok && value == "true" {
+		for _, a := range macro {
+			g.apply(values, sources, a, source)
+		}
+	}
+}
+
+// rules returns the rules applying to the files of the directory, in
+// precedence order.
+func (g *GitAttributes) rules(dir string) ([]attributeRule, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	rules := append([]attributeRule{}, g.global...)
+	rules = append(rules, g.dirs[""]...)
+	base := ""
+
+	for _, s := range strings.Split(dir, "/") {
+		if s == "." || s == "" {
+			break
+		}
+
+		base = path.Join(base, s)
+
+		dirRules, ok := g.dirs[base]
+		if !ok {
+			var err error
+
+			dirRules, err = g.load(filepath.Join(g.root, filepath.FromSlash(base), attributesFilename), base, false)
+			if err != nil {
+				return nil, err
+			}
+
+			g.dirs[base] = dirRules
+		}
+
+		rules = append(rules, dirRules...)
+	}
+
+	return append(rules, g.info...), nil
+}
+
+// load reads the rules of the file, a missing file is fine.
+func (g *GitAttributes) load(filename string, base string, macros bool) ([]attributeRule, error) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("cannot open %s. %w", filename, err)
+	}
+
+	defer fp.Close()
+
+	return g.parse(fp, filename, base, macros)
+}
+
+// parse reads the rules, the macros are only defined when allowed.
+func (g *GitAttributes) parse(r io.Reader, source string, base string, macros bool) ([]attributeRule, error) {
+	rules := make([]attributeRule, 0)
+	scanner := bufio.NewScanner(r)
+
+	for i := 1; scanner.Scan(); i++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if strings.HasPrefix(fields[0], "[attr]") {
+			if macros {
+				g.macros[strings.TrimPrefix(fields[0], "[attr]")] = fields[1:]
+			}
+
+			continue
+		}
+
+		// The negated and the directory patterns never match files.
+		p, ok := parseGitignorePattern(strings.Trim(fields[0], `"`))
+		if !ok || p.negate || p.dirOnly {
+			continue
+		}
+
+		p.source = fmt.Sprintf("%s:%d", source, i)
+		p.base = base
+		rules = append(rules, attributeRule{pattern: p, attrs: fields[1:]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read %s. %w", source, err)
+	}
+
+	return rules, nil
+}
+
+// CheckGitAttributes verifies that the eol attribute of the file doesn't
+// contradict its end_of_line, unless the rule is disabled.
+func CheckGitAttributes(rules *RuleSet, d *editorconfig.Definition, filename string, attrs Attributes) error {
+	if attrs.EOL == "" || attrs.Binary || d.EndOfLine == "" || d.EndOfLine == UnsetValue {
+		return nil
+	}
+
+	if !rules.Enabled(RuleEndOfLine) {
+		return nil
+	}
+
+	if attrs.EOL == d.EndOfLine {
+		return nil
+	}
+
+	return ValidationError{
+		Rule:     RuleEndOfLine,
+		Severity: rules.Severity(RuleEndOfLine),
+		Filename: filename,
+		Message: fmt.Sprintf(
+			"end_of_line is %s but %s sets eol=%s",
+			d.EndOfLine,
+			attrs.Sources["eol"],
+			attrs.EOL,
+		),
+	}
+}
@@ -0,0 +1,125 @@
+package eclint_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+	"gitlab.com/greut/eclint"
+)
+
+func TestGitAttributes(t *testing.T) { //nolint:paralleltest
+	gitRepo(t)
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	write := func(filename, content string) {
+		t.Helper()
+
+		if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(".gitattributes", strings.Join([]string{
+		"# comment",
+		"[attr]generated linguist-generated -diff",
+		"*.png binary",
+		"*.dat -text",
+		"*.bat text eol=crlf",
+		"*.pb.go generated",
+		"vendor/** linguist-vendored",
+		"docs/ -text",
+		"",
+	}, "\n"))
+	write("src/.gitattributes", "keep.pb.go -linguist-generated\nlocal.bat eol=lf\n")
+	write(filepath.Join(".git", "info", "attributes"), "override.dat text\n")
+
+	tests := []struct {
+		Name     string
+		Filename string
+		Skip     bool
+		Reason   string
+		EOL      string
+	}{
+		{Name: "text", Filename: "a.txt"},
+		{Name: "binary", Filename: "img/a.png", Skip: true, Reason: ".gitattributes:3"},
+		{Name: "not text", Filename: "a.dat", Skip: true, Reason: ".gitattributes:4"},
+		{Name: "info overrides", Filename: "override.dat"},
+		{Name: "eol", Filename: "a.bat", EOL: "crlf"},
+		{Name: "nested eol", Filename: "src/local.bat", EOL: "lf"},
+		{Name: "macro", Filename: "src/a.pb.go", Skip: true, Reason: ".gitattributes:6"},
+		{Name: "nested unset", Filename: "src/keep.pb.go"},
+		{Name: "vendored", Filename: "vendor/lib/a.go", Skip: true, Reason: ".gitattributes:7"},
+		{Name: "directory pattern", Filename: "docs/a.md"},
+		{Name: "outside", Filename: "../a.png"},
+	}
+
+	g, err := eclint.NewGitAttributes(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range tests {
+		attrs, err := g.Get(filepath.FromSlash(tc.Filename))
+		if err != nil {
+			t.Fatalf("%s: no errors were expected, got %s", tc.Name, err)
+		}
+
+		skip, reason := attrs.Skip()
+		if skip != tc.Skip {
+			t.Errorf("%s: expected skip to be %v, got %v", tc.Name, tc.Skip, skip)
+		}
+
+		if !strings.Contains(reason, tc.Reason) {
+			t.Errorf("%s: expected the reason to contain %q, got %q", tc.Name, tc.Reason, reason)
+		}
+
+		if attrs.EOL != tc.EOL {
+			t.Errorf("%s: expected eol %q, got %q", tc.Name, tc.EOL, attrs.EOL)
+		}
+	}
+}
+
+func TestCheckGitAttributes(t *testing.T) {
+	tests := []struct {
+		Name      string
+		EndOfLine string
+		Attrs     eclint.Attributes
+		Conflict  bool
+	}{
+		{Name: "none", EndOfLine: "lf"},
+		{Name: "unset", EndOfLine: "unset", Attrs: eclint.Attributes{EOL: "crlf"}},
+		{Name: "same", EndOfLine: "crlf", Attrs: eclint.Attributes{EOL: "crlf"}},
+		{Name: "binary", EndOfLine: "lf", Attrs: eclint.Attributes{EOL: "crlf", Binary: true}},
+		{Name: "conflict", EndOfLine: "lf", Attrs: eclint.Attributes{EOL: "crlf"}, Conflict: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			def := &editorconfig.Definition{EndOfLine: tc.EndOfLine}
+
+			err := eclint.CheckGitAttributes(nil, def, "a.txt", tc.Attrs)
+			if (err != nil) != tc.Conflict {
+				t.Fatalf("expected a conflict to be %v, got %v", tc.Conflict, err)
+			}
+
+			var ve eclint.ValidationError
+			if err != nil && (!errors.As(err, &ve) || ve.Rule != eclint.RuleEndOfLine) {
+				t.Errorf("an end_of_line validation error was expected, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+package eclint
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// gitignorePattern is a pattern of a .gitignore file.
+type gitignorePattern struct {
+	// source is the file and line number defining the pattern.
+	source string
+	// base is the directory of the .gitignore file, relative to the root,
+	// the pattern only applies to the paths within it.
+	base     string
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// gitignore matches the paths against the patterns using the rules of
+// gitignore(5), the last matching pattern wins.
+//
+// The patterns are to be added from the lowest precedence to the highest
+// one: the global excludes file, .git/info/exclude then the .gitignore
+// files from the root down to the deepest directory.
+type gitignore struct {
+	patterns []gitignorePattern
+}
+
+// load reads the patterns of the file, a missing file is fine.
+func (g *gitignore) load(filename string, base string) error {
+	fp, err := os.Open(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("cannot open %s. %w", filename, err)
+	}
+
+	defer fp.Close()
+
+	return g.parse(fp, filename, base)
+}
+
+// parse reads the patterns, base is the directory they apply to using
+// forward slashes, empty for the root.
+func (g *gitignore) parse(r io.Reader, source string, base string) error {
+	scanner := bufio.NewScanner(r)
+
+	for i := 1; scanner.Scan(); i++ {
+		p, ok := parseGitignorePattern(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		p.source = fmt.Sprintf("%s:%d", source, i)
+		p.base = base
+		g.patterns = append(g.patterns, p)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("cannot read %s. %w", source, err)
+	}
+
+	return nil
+}
+
+// parseGitignorePattern parses a line, the blank lines and comments are
+// left out.
+func parseGitignorePattern(line string) (gitignorePattern, bool) {
+	p := gitignorePattern{}
+
+	line = strings.TrimSuffix(line, "\r")
+
+	// The trailing spaces are removed, unless escaped.
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+
+	if line == "" || strings.HasPrefix(line, "#") {
+		return p, false
+	}
+
+	switch {
+	case strings.HasPrefix(line, "!"):
+		p.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	if line == "" {
+		return p, false
+	}
+
+	// A pattern without any slash matches at any level.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	if !anchored {
+		p.segments = append(p.segments, "**")
+	}
+
+	for _, s := range strings.Split(line, "/") {
+		// path.Match negates the brackets using ^ only.
+		p.segments = append(p.segments, strings.ReplaceAll(s, "[!", "[^"))
+	}
+
+	return p, true
+}
+
+// match returns the last pattern matching the path, relative to the root and
+// using forward slashes. When it's a negated one, the path is not ignored.
+func (g *gitignore) match(name string, isDir bool) *gitignorePattern {
+	if g == nil {
+		return nil
+	}
+
+	for i := len(g.patterns) - 1; i >= 0; i-- {
+		if p := &g.patterns[i]; p.matches(name, isDir) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// matches tells whether the pattern matches the path, relative to the root.
+func (p *gitignorePattern) matches(name string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	rel := name
+
+	if p.base != "" {
+		if !strings.HasPrefix(name, p.base+"/") {
+			return false
+		}
+
+		rel = name[len(p.base)+1:]
+	}
+
+	return matchSegments(p.segments, strings.Split(rel, "/"))
+}
+
+// ignored tells whether the path is ignored.
+func (g *gitignore) ignored(name string, isDir bool) bool {
+	p := g.match(name, isDir)
+
+	return p != nil && !p.negate
+}
+
+// matchSegments matches the path segments against the pattern ones, ** being
+// any number of directories. A trailing ** matches everything inside.
+func matchSegments(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return len(name) > 0
+			}
+
+			for i := range name {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+
+		// A malformed pattern matches nothing, like git does.
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0
+}
+
+// newGitignore loads the patterns applying to the given directory, it's
+// relative to the root of its git repository, if any, which is returned
+// as well.
+//
+// The .gitignore files of the directory itself and below are left out, they
+// are loaded while walking it.
+func newGitignore(dir string) (*gitignore, string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot resolve %s. %w", dir, err)
+	}
+
+	g := &gitignore{}
+
+	root, gitDir := findGitDir(abs)
+	if root == "" {
+		// Outside of a repository, the walked directory acts as the root.
+		if err := g.load(globalExcludesFile(""), ""); err != nil {
+			return nil, "", err
+		}
+
+		return g, "", nil
+	}
+
+	if err := g.load(globalExcludesFile(gitDir), ""); err != nil {
+		return nil, "", err
+	}
+
+	if err := g.load(filepath.Join(gitDir, "info", "exclude"), ""); err != nil {
+		return nil, "", err
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot resolve %s. %w", dir, err)
+	}
+
+	prefix := ""
+	if rel != "." {
+		prefix = filepath.ToSlash(rel)
+	}
+
+	// The .gitignore files of the parent directories, from the root.
+	base := ""
+
+	for _, s := range strings.Split(prefix, "/") {
+		if s == "" {
+			break
+		}
+
+		if err := g.load(filepath.Join(root, filepath.FromSlash(base), ".gitignore"), base); err != nil {
+			return nil, "", err
+		}
+
+		base = path.Join(base, s)
+	}
+
+	return g, prefix, nil
+}
+
+// findGitDir looks for the root of the git repository containing the
+// directory, and its git directory. Both are empty when there is none.
+func findGitDir(dir string) (string, string) {
+	for {
+		gitDir := filepath.Join(dir, ".git")
+
+		if stat, err := os.Stat(gitDir); err == nil {
+			if !stat.IsDir() {
+				// A worktree, or a submodule, refers to its git directory.
+				gitDir = readGitFile(dir, gitDir)
+			}
+
+			return dir, commonGitDir(gitDir)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+
+		dir = parent
+	}
+}
+
+// readGitFile reads the "gitdir: path" of a .git file.
+func readGitFile(dir string, filename string) string {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return filename
+	}
+
+	gitDir := strings.TrimSpace(strings.TrimPrefix(string(content), "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+
+	return gitDir
+}
+
+// commonGitDir resolves the git directory shared by the worktrees, it holds
+// the configuration and info/exclude.
+func commonGitDir(gitDir string) string {
+	content, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+
+	common := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(gitDir, common)
+	}
+
+	return common
+}
+
+// globalExcludesFile finds the core.excludesFile of the git configuration,
+// it defaults to $XDG_CONFIG_HOME/git/ignore.
+func globalExcludesFile(gitDir string) string {
+	return globalGitFile(gitDir, "excludesfile", "ignore")
+}
+
+// globalGitFile finds the file set by the core key of the git configuration,
+// it defaults to the given name within $XDG_CONFIG_HOME/git.
+//
+// The configuration of the repository has the last word over the global
+// ones.
+func globalGitFile(gitDir string, key string, name string) string {
+	home, _ := os.UserHomeDir()
+
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if xdg == "" && home != "" {
+		xdg = filepath.Join(home, ".config")
+	}
+
+	configs := make([]string, 0, 3)
+
+	if xdg != "" {
+		configs = append(configs, filepath.Join(xdg, "git", "config"))
+	}
+
+	if home != "" {
+		configs = append(configs, filepath.Join(home, ".gitconfig"))
+	}
+
+	if gitDir != "" {
+		configs = append(configs, filepath.Join(gitDir, "config"))
+	}
+
+	filename := ""
+
+	for _, config := range configs {
+		if value := readCoreConfig(config, key); value != "" {
+			filename = value
+		}
+	}
+
+	if filename == "" && xdg != "" {
+		filename = filepath.Join(xdg, "git", name)
+	}
+
+	if strings.HasPrefix(filename, "~/") && home != "" {
+		filename = filepath.Join(home, filename[2:])
+	}
+
+	return filename
+}
+
+// readCoreConfig reads the value of the core key of a git configuration
+// file, the includes are not followed.
+func readCoreConfig(filename string, name string) string {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return ""
+	}
+
+	defer fp.Close()
+
+	value := ""
+	section := ""
+	scanner := bufio.NewScanner(fp)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "[") {
+			section = strings.ToLower(strings.TrimSpace(strings.Trim(line, "[]")))
+
+			continue
+		}
+
+		if section != "core" {
+			continue
+		}
+
+		key, v, ok := strings.Cut(line, "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), name) {
+			continue
+		}
+
+		v = strings.TrimSpace(v)
+
+		if strings.HasPrefix(v, `"`) {
+			v, _, _ = strings.Cut(v[1:], `"`)
+		} else if i := strings.IndexAny(v, "#;"); i >= 0 {
+			v = strings.TrimSpace(v[:i])
+		}
+
+		value = v
+	}
+
+	return value
+}
@@ -0,0 +1,87 @@
+package eclint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGitignore(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Patterns string
+		Path     string
+		IsDir    bool
+		Ignored  bool
+	}{
+		{Name: "basename", Patterns: "*.log", Path: "a/b/c.log", Ignored: true},
+		{Name: "no match", Patterns: "*.log", Path: "a/b/c.txt"},
+		{Name: "comment", Patterns: "# *.log", Path: "c.log"},
+		{Name: "escaped hash", Patterns: `\#c.log`, Path: "#c.log", Ignored: true},
+		{Name: "trailing spaces", Patterns: "c.log  ", Path: "c.log", Ignored: true},
+		{Name: "anchored", Patterns: "/c.log", Path: "a/c.log"},
+		{Name: "anchored root", Patterns: "/c.log", Path: "c.log", Ignored: true},
+		{Name: "middle slash", Patterns: "a/c.log", Path: "b/a/c.log"},
+		{Name: "directory", Patterns: "build/", Path: "a/build", IsDir: true, Ignored: true},
+		{Name: "directory only", Patterns: "build/", Path: "a/build"},
+		{Name: "leading stars", Patterns: "**/build", Path: "a/b/build", Ignored: true},
+		{Name: "middle stars", Patterns: "a/**/c.log", Path: "a/c.log", Ignored: true},
+		{Name: "middle stars deep", Patterns: "a/**/c.log", Path: "a/b/d/c.log", Ignored: true},
+		{Name: "trailing stars", Patterns: "a/**", Path: "a/b/c.log", Ignored: true},
+		{Name: "trailing stars itself", Patterns: "a/**", Path: "a", IsDir: true},
+		{Name: "question mark", Patterns: "c.?og", Path: "c.log", Ignored: true},
+		{Name: "brackets", Patterns: "c.[!t]og", Path: "c.log", Ignored: true},
+		{Name: "negated brackets", Patterns: "c.[!l]og", Path: "c.log"},
+		{Name: "negation", Patterns: "*.log\n!keep.log", Path: "keep.log"},
+		{Name: "negation order", Patterns: "!keep.log\n*.log", Path: "keep.log", Ignored: true},
+		{Name: "star no slash", Patterns: "a/*.log", Path: "a/b/c.log"},
+		{Name: "malformed", Patterns: "c.[log", Path: "c.[log"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			g := &gitignore{}
+			if err := g.parse(strings.NewReader(tc.Patterns), ".gitignore", ""); err != nil {
+				t.Fatal(err)
+			}
+
+			if ignored := g.ignored(tc.Path, tc.IsDir); ignored != tc.Ignored {
+				t.Errorf("%s with %q: expected ignored to be %v", tc.Path, tc.Patterns, tc.Ignored)
+			}
+		})
+	}
+}
+
+func TestGitignoreBase(t *testing.T) {
+	t.Parallel()
+
+	g := &gitignore{}
+
+	if err := g.parse(strings.NewReader("*.log\n"), ".gitignore", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.parse(strings.NewReader("!keep.log\n/c.txt\n"), "a/.gitignore", "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, ignored := range map[string]bool{
+		"keep.log":   true,
+		"a/keep.log": false,
+		"a/b.log":    true,
+		"a/c.txt":    true,
+		"a/b/c.txt":  false,
+		"c.txt":      false,
+	} {
+		if g.ignored(name, false) != ignored {
+			t.Errorf("%s: expected ignored to be %v", name, ignored)
+		}
+	}
+
+	if p := g.match("a/keep.log", false); p == nil || p.source != "a/.gitignore:1" {
+		t.Errorf("the source of the pattern was expected, got %v", p)
+	}
+}
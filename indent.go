@@ -0,0 +1,274 @@
+package eclint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// reindenter rewrites the indentation of the lines using the indent_style
+// and indent_size, the indentation levels found in the file are kept.
+//
+// The width of the indentation is measured in columns, the tabs going to the
+// next multiple of tab_width, and divided by the unit of the file to get the
+// level. What remains is considered as alignment and kept as spaces.
+//
+// A line whose indentation changes from tabs to spaces, or the other way
+// around, is an indent_style fix, otherwise an indent_size one. The lines
+// the linter accepts, or whose rule is disabled, are kept, and the unit of
+// the file is kept when indent_size is disabled.
+type reindenter struct {
+	style    string
+	size     int
+	tabWidth int
+	unit     int
+	// checkedSize is the indent_size the linter checks the lines against.
+	checkedSize int
+	rules       *RuleSet
+	comments    blockComments
+	// the original width and the new indentation of the line opening the
+	// current block comment.
+	commentColumns int
+	commentPrefix  []byte
+}
+
+// newReindenter builds the reindenter of the given lines, nil is returned
+// when the indentation cannot be fixed.
+func newReindenter(def *definition, lines [][]byte) (*reindenter, error) {
+	r := &reindenter{
+		style:       def.IndentStyle,
+		size:        def.IndentSize,
+		tabWidth:    def.TabWidth,
+		checkedSize: def.IndentSize,
+		rules:       def.rules,
+		comments: blockComments{
+			start: def.BlockCommentStart,
+			end:   def.BlockCommentEnd,
+		},
+	}
+
+	if r.tabWidth <= 0 {
+		r.tabWidth = r.size
+	}
+
+	if r.tabWidth <= 0 {
+		r.tabWidth = DefaultTabWidth
+	}
+
+	expected := r.size
+
+	switch r.style {
+	case SpaceValue:
+		if r.size <= 0 {
+			return nil, nil //nolint:nilnil
+		}
+	case TabValue:
+		expected = r.tabWidth
+	case "", UnsetValue:
+		return nil, nil //nolint:nilnil
+	default:
+		return nil, fmt.Errorf(
+			"%w: %q is an invalid value of indent_style, want tab or space",
+			ErrConfiguration,
+			r.style,
+		)
+	}
+
+	r.unit = detectIndentUnit(lines, r.tabWidth, expected, r.comments)
+
+	if !r.rules.Enabled(RuleIndentSize) {
+		r.size = r.unit
+	}
+
+	return r, nil
+}
+
+// detectIndentUnit finds the number of columns of one indentation level.
+//
+// When every indentation is a multiple of the expected unit, it is kept.
+// Otherwise, the most common increase of indentation between two lines
+// wins, as long as more lines line up on it than on the expected unit. The
+// lines inside block comments are left out.
+func detectIndentUnit(lines [][]byte, tabWidth int, expected int, comments blockComments) int {
+	counts := make(map[int]int)
+	indents := make([]int, 0, len(lines))
+	consistent := true
+	previous := 0
+
+	for _, line := range lines {
+		if inside, _ := comments.next(line); inside || isBlankLine(line) {
+			continue
+		}
+
+		columns, _ := measureIndent(line, tabWidth)
+		indents = append(indents, columns)
+
+		if columns%expected != 0 {
+			consistent = false
+		}
+
+		if columns > previous {
+			counts[columns-previous]++
+		}
+
+		previous = columns
+	}
+
+	if consistent {
+		return expected
+	}
+
+	// The expected unit wins the ties, then the smallest one.
+	unit := expected
+
+	for delta, count := range counts {
+		switch {
+		case count > counts[unit]:
+			unit = delta
+		case count < counts[unit] || unit == expected:
+		case delta == expected || delta < unit:
+			unit = delta
+		}
+	}
+
+	// The continuation lines may be aligned deeper than one level, e.g. the
+	// arguments of a call, and outnumber the actual increases.
+	aligned := 0
+
+	for _, columns := range indents {
+		switch {
+		case columns%unit == 0 && columns%expected != 0:
+			aligned++
+		case columns%unit != 0 && columns%expected == 0:
+			aligned--
+		}
+	}
+
+	if aligned <= 0 {
+		return expected
+	}
+
+	return unit
+}
+
+// fix rewrites the indentation of the line, the rule of the fix is empty
+// when it's kept.
+func (r *reindenter) fix(data []byte) ([]byte, string) {
+	inside, opening := r.comments.next(data)
+
+	if isBlankLine(data) {
+		return data, ""
+	}
+
+	columns, n := measureIndent(data, r.tabWidth)
+
+	var prefix []byte
+
+	if inside && columns >= r.commentColumns {
+		// The layout of the block comment is kept relative to its opening.
+		prefix = append(append([]byte{}, r.commentPrefix...), bytes.Repeat([]byte{space}, columns-r.commentColumns)...)
+	} else {
+		prefix = r.indentation(columns)
+	}
+
+	rule := ""
+
+	switch {
+	case bytes.Equal(prefix, data[:n]):
+	case bytes.Count(prefix, []byte{tab}) != bytes.Count(data[:n], []byte{tab}):
+		rule = RuleIndentStyle
+	default:
+		rule = RuleIndentSize
+	}
+
+	if rule != "" && (!r.rules.Enabled(rule) || r.accepts(data)) {
+		prefix = append([]byte{}, data[:n]...)
+		rule = ""
+	}
+
+	if opening {
+		r.commentColumns = columns
+		r.commentPrefix = prefix
+	}
+
+	if rule == "" {
+		return data, ""
+	}
+
+	return append(prefix, data[n:]...), rule
+}
+
+// accepts tells whether the linter is fine with the indentation of the line,
+// the errors of the disabled rules aside.
+func (r *reindenter) accepts(data []byte) bool {
+	err := indentStyle(r.style, r.checkedSize, data)
+
+	var ve ValidationError
+	if errors.As(err, &ve) {
+		return !r.rules.Enabled(ve.Rule)
+	}
+
+	return err == nil
+}
+
+// indentation builds the indentation matching the given width.
+func (r *reindenter) indentation(columns int) []byte {
+	level := columns / r.unit
+	alignment := bytes.Repeat([]byte{space}, columns%r.unit)
+
+	if r.style == TabValue {
+		return append(bytes.Repeat([]byte{tab}, level), alignment...)
+	}
+
+	return append(bytes.Repeat([]byte{space}, level*r.size), alignment...)
+}
+
+// measureIndent returns the width in columns of the indentation and its
+// length in bytes.
+func measureIndent(data []byte, tabWidth int) (int, int) {
+	columns := 0
+
+	for i, b := range data {
+		switch b {
+		case space:
+			columns++
+		case tab:
+			columns = (columns/tabWidth + 1) * tabWidth
+		default:
+			return columns, i
+		}
+	}
+
+	return columns, len(data)
+}
+
+// isBlankLine tells whether the line only contains whitespaces.
+func isBlankLine(data []byte) bool {
+	return len(bytes.Trim(data, " \t\r\n")) == 0
+}
+
+// blockComments keeps track of the block comments while reading the lines.
+type blockComments struct {
+	start  []byte
+	end    []byte
+	inside bool
+}
+
+// next tells whether the line is inside a block comment, or opening one.
+func (b *blockComments) next(data []byte) (bool, bool) {
+	if b.inside {
+		b.inside = !isBlockCommentEnd(b.end, data)
+
+		return true, false
+	}
+
+	if b.start == nil || b.end == nil || !isBlockCommentStart(b.start, data) {
+		return false, false
+	}
+
+	// The comment may be closed on the same line.
+	start := bytes.Index(data, b.start) + len(b.start)
+	b.inside = !bytes.Contains(data[start:], b.end)
+
+	return false, b.inside
+}
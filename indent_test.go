@@ -0,0 +1,241 @@
+package eclint
+
+import (
+	"testing"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReindent(t *testing.T) {
+	tests := []struct {
+		Name        string
+		IndentStyle string
+		IndentSize  string
+		TabWidth    int
+		Lines       []string
+		Expected    []string
+	}{
+		{
+			Name:        "two spaces to four",
+			IndentStyle: "space",
+			IndentSize:  "4",
+			Lines:       []string{"a {\n", "  b {\n", "    c\n", "  }\n", "}\n"},
+			Expected:    []string{"a {\n", "    b {\n", "    c\n", "    }\n", "}\n"},
+		}, {
+			Name:        "aligned continuation lines",
+			IndentStyle: "space",
+			IndentSize:  "4",
+			Lines: []string{
+				"def f(a,\n", "      b):\n", "    x = g(a,\n", "          b)\n",
+				"    y = h(a,\n", "          b)\n", "    if x:\n", "        return y\n",
+			},
+			Expected: []string{
+				"def f(a,\n", "      b):\n", "    x = g(a,\n", "          b)\n",
+				"    y = h(a,\n", "          b)\n", "    if x:\n", "        return y\n",
+			},
+		}, {
+			Name:        "already indented",
+			IndentStyle: "space",
+			IndentSize:  "2",
+			Lines:       []string{"a {\n", "    b\n", "  c\n"},
+			Expected:    []string{"a {\n", "    b\n", "  c\n"},
+		}, {
+			Name:        "spaces to tabs",
+			IndentStyle: "tab",
+			IndentSize:  "4",
+			Lines:       []string{"a {\n", "    b {\n", "        c\n", "    }\n", "}\n"},
+			Expected:    []string{"a {\n", "\tb {\n", "\t\tc\n", "\t}\n", "}\n"},
+		}, {
+			Name:        "tabs to spaces",
+			IndentStyle: "space",
+			IndentSize:  "2",
+			TabWidth:    8,
+			Lines:       []string{"a {\n", "\tb {\n", "\t\tc\n", "\t}\n", "}\n"},
+			Expected:    []string{"a {\n", "        b {\n", "                c\n", "        }\n", "}\n"},
+		}, {
+			Name:        "tab alignment",
+			IndentStyle: "tab",
+			IndentSize:  "4",
+			Lines:       []string{"a {\n", "    b(c,\n", "      d)\n", "    e\n", "}\n"},
+			Expected:    []string{"a {\n", "\tb(c,\n", "\t  d)\n", "\te\n", "}\n"},
+		}, {
+			Name:        "mixed tabs and spaces",
+			IndentStyle: "space",
+			IndentSize:  "4",
+			TabWidth:    4,
+			Lines:       []string{"a {\n", "  \tb\n", "\t  \tc\n", "}\n"},
+			Expected:    []string{"a {\n", "    b\n", "        c\n", "}\n"},
+		}, {
+			Name:        "blank lines",
+			IndentStyle: "space",
+			IndentSize:  "4",
+			Lines:       []string{"a {\n", "  b\n", "  \n", "  c\n", "}\n"},
+			Expected:    []string{"a {\n", "    b\n", "  \n", "    c\n", "}\n"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			def, err := newDefinition(&editorconfig.Definition{
+				IndentStyle: tc.IndentStyle,
+				IndentSize:  tc.IndentSize,
+				TabWidth:    tc.TabWidth,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertReindent(t, def, tc.Lines, tc.Expected)
+		})
+	}
+}
+
+func TestReindentBlockComment(t *testing.T) {
+	def, err := newDefinition(&editorconfig.Definition{
+		IndentStyle: "tab",
+		IndentSize:  "4",
+		Raw: map[string]string{
+			"block_comment_start": "/*",
+			"block_comment":       "*",
+			"block_comment_end":   "*/",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []string{
+		"a {\n",
+		"  /*\n",
+		"   * b\n",
+		"   *   c\n",
+		"   */\n",
+		"  /* d */\n",
+		"  e\n",
+		"}\n",
+	}
+	expected := []string{
+		"a {\n",
+		"\t/*\n",
+		"\t * b\n",
+		"\t *   c\n",
+		"\t */\n",
+		"\t/* d */\n",
+		"\te\n",
+		"}\n",
+	}
+
+	assertReindent(t, def, lines, expected)
+}
+
+func TestReindentRules(t *testing.T) {
+	def, err := newDefinition(&editorconfig.Definition{IndentStyle: "space", IndentSize: "4", TabWidth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []string{"a {\n", "  b {\n", "\t\tc\n", "  }\n", "}\n"}
+
+	tests := []struct {
+		Name     string
+		Disabled []string
+		Expected []string
+		Rules    []string
+	}{
+		{
+			Name:     "both",
+			Expected: []string{"a {\n", "    b {\n", "        c\n", "    }\n", "}\n"},
+			Rules:    []string{"", RuleIndentSize, RuleIndentStyle, RuleIndentSize, ""},
+		}, {
+			Name:     "indent_size disabled",
+			Disabled: []string{RuleIndentSize},
+			Expected: []string{"a {\n", "  b {\n", "    c\n", "  }\n", "}\n"},
+			Rules:    []string{"", "", RuleIndentStyle, "", ""},
+		}, {
+			Name:     "indent_style disabled",
+			Disabled: []string{RuleIndentStyle},
+			Expected: []string{"a {\n", "    b {\n", "\t\tc\n", "    }\n", "}\n"},
+			Rules:    []string{"", RuleIndentSize, "", RuleIndentSize, ""},
+		},
+	}
+
+	for _, tc := range tests {
+		rules, err := NewRuleSet(tc.Disabled, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := make([][]byte, len(lines))
+		for i, line := range lines {
+			data[i] = []byte(line)
+		}
+
+		def.rules = rules
+
+		r, err := newReindenter(def, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := make([]string, len(lines))
+		fixes := make([]string, len(lines))
+
+		for i, line := range data {
+			fixed, rule := r.fix(line)
+			result[i] = string(fixed)
+			fixes[i] = rule
+		}
+
+		if !cmp.Equal(tc.Expected, result) {
+			t.Errorf("%s: diff %s", tc.Name, cmp.Diff(tc.Expected, result))
+		}
+
+		if !cmp.Equal(tc.Rules, fixes) {
+			t.Errorf("%s: rules diff %s", tc.Name, cmp.Diff(tc.Rules, fixes))
+		}
+	}
+}
+
+func TestReindentUnset(t *testing.T) {
+	for _, style := range []string{"", UnsetValue} {
+		def, err := newDefinition(&editorconfig.Definition{IndentStyle: style})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := newReindenter(def, nil)
+		if err != nil || r != nil {
+			t.Errorf("%q: nothing was expected, got %v, %v", style, r, err)
+		}
+	}
+}
+
+func assertReindent(t *testing.T, def *definition, lines []string, expected []string) {
+	t.Helper()
+
+	data := make([][]byte, len(lines))
+	for i, line := range lines {
+		data[i] = []byte(line)
+	}
+
+	r, err := newReindenter(def, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := make([]string, len(lines))
+
+	for i, line := range data {
+		fixed, _ := r.fix(line)
+		result[i] = string(fixed)
+	}
+
+	if !cmp.Equal(expected, result) {
+		t.Errorf("diff %s", cmp.Diff(expected, result))
+	}
+}
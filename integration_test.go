@@ -2,9 +2,13 @@ package eclint_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/editorconfig/editorconfig-core-go/v2"
 	"gitlab.com/greut/eclint"
 )
 
@@ -140,3 +144,60 @@ func TestInsertFinalNewlineInvalidSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestLintReader(t *testing.T) {
+	ctx := context.TODO()
+	trim := true
+	def := &editorconfig.Definition{
+		EndOfLine:              editorconfig.EndOfLineLf,
+		TrimTrailingWhitespace: &trim,
+	}
+
+	content := "hello \nworld\n"
+
+	errs := eclint.LintReader(ctx, nil, def, "virtual.txt", strings.NewReader(content), int64(len(content)))
+	if len(errs) != 1 {
+		t.Fatalf("one error was expected, got %v", errs)
+	}
+
+	var ve eclint.ValidationError
+	if ok := errors.As(errs[0], &ve); !ok || ve.Filename != "virtual.txt" || ve.Rule != eclint.RuleTrimTrailingWhitespace {
+		t.Errorf("a trailing whitespace error on the virtual file was expected, got %v", errs[0])
+	}
+}
+
+func TestFixReader(t *testing.T) {
+	ctx := context.TODO()
+	trim := true
+	def := &editorconfig.Definition{
+		EndOfLine:              editorconfig.EndOfLineLf,
+		TrimTrailingWhitespace: &trim,
+	}
+
+	content := "hello \nworld\n"
+
+	out, report, err := eclint.FixReader(ctx, nil, def, "virtual.txt", strings.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(result) != "hello\nworld\n" {
+		t.Errorf("the trailing whitespace was expected to be removed, got %q", result)
+	}
+
+	if report.Filename != "virtual.txt" || len(report.Changes) != 1 {
+		t.Errorf("one change was expected, got %v", report)
+	}
+
+	content = "hello\n"
+
+	out, report, err = eclint.FixReader(ctx, nil, def, "virtual.txt", strings.NewReader(content), int64(len(content)))
+	if err != nil || out != nil || report != nil {
+		t.Errorf("nothing was expected to be fixed, got %v, %v, %v", out, report, err)
+	}
+}
@@ -32,18 +32,27 @@ const (
 	Latin1 = "latin1"
 )
 
-// Lint does the hard work of validating the given file.
+// Lint does the hard work of validating the given file, every rule is
+// enabled as an error.
 func Lint(ctx context.Context, filename string) []error {
 	def, err := editorconfig.GetDefinitionForFilename(filename)
 	if err != nil {
 		return []error{fmt.Errorf("cannot open file %s. %w", filename, err)}
 	}
 
-	return LintWithDefinition(ctx, def, filename)
+	return LintWithDefinition(ctx, nil, def, filename)
 }
 
 // LintWithDefinition does the hard work of validating the given file.
-func LintWithDefinition(ctx context.Context, d *editorconfig.Definition, filename string) []error { //nolint:funlen
+//
+// The rules tell which checks run and their severity, nil enables them all
+// as errors.
+func LintWithDefinition( //nolint:funlen
+	ctx context.Context,
+	rules *RuleSet,
+	d *editorconfig.Definition,
+	filename string,
+) []error {
 	log := logr.FromContextOrDiscard(ctx)
 
 	def, err := newDefinition(d)
@@ -51,6 +60,8 @@ func LintWithDefinition(ctx context.Context, d *editorconfig.Definition, filenam
 		return []error{err}
 	}
 
+	def.rules = rules
+
 	stat, err := os.Stat(filename)
 	if err != nil {
 		return []error{fmt.Errorf("cannot stat %s. %w", filename, err)}
@@ -84,11 +95,55 @@ func LintWithDefinition(ctx context.Context, d *editorconfig.Definition, filenam
 		return nil
 	}
 
-	charset, isBinary, err := ProbeCharsetOrBinary(ctx, r, def.Charset)
+	return lint(ctx, def, filename, r, fileSize)
+}
+
+// LintReader validates the content read from r as if it was the given file.
+//
+// It's meant for the buffers that aren't on disk, like the standard input
+// of an editor integration, the definition is the one of the virtual path.
+func LintReader(
+	ctx context.Context,
+	rules *RuleSet,
+	d *editorconfig.Definition,
+	filename string,
+	r io.Reader,
+	size int64,
+) []error {
+	def, err := newDefinition(d)
 	if err != nil {
 		return []error{err}
 	}
 
+	def.rules = rules
+
+	return lint(ctx, def, filename, bufio.NewReader(r), size)
+}
+
+// lint validates the content of the reader.
+func lint(ctx context.Context, def *definition, filename string, r *bufio.Reader, fileSize int64) []error {
+	log := logr.FromContextOrDiscard(ctx)
+	rules := def.rules
+
+	expectedCharset := def.Charset
+	if !rules.Enabled(RuleCharset) {
+		// the charset is still probed to detect binary and UTF-16 files.
+		expectedCharset = ""
+	}
+
+	charset, isBinary, err := ProbeCharsetOrBinary(ctx, r, expectedCharset)
+	if err != nil {
+		var ve ValidationError
+		if ok := errors.As(err, &ve); ok {
+			ve.Filename = filename
+			ve.Severity = rules.Severity(ve.Rule)
+
+			return []error{ve}
+		}
+
+		return []error{err}
+	}
+
 	if isBinary {
 		log.V(2).Info("binary file detected and skipped")
 
@@ -133,6 +188,9 @@ func validate( //nolint:cyclop,gocognit,funlen
 	charset string,
 	def *definition,
 ) []error {
+	rules := def.rules
+	suppressions := newSuppressions(def)
+
 	return ReadLines(r, fileSize, func(index int, data []byte, isEOF bool) error {
 		var err error
 
@@ -140,12 +198,18 @@ func validate( //nolint:cyclop,gocognit,funlen
 			return fmt.Errorf("read lines got interrupted: %w", ctx.Err())
 		}
 
+		// The rules may be disabled or suppressed inline for this line only.
+		suppressed := suppressions.scan(data)
+		enabled := func(rule string) bool {
+			return rules.Enabled(rule) && !suppressed.has(rule)
+		}
+
 		if isEOF {
-			if def.InsertFinalNewline != nil {
+			if def.InsertFinalNewline != nil && enabled(RuleInsertFinalNewline) {
 				err = checkInsertFinalNewline(data, *def.InsertFinalNewline)
 			}
 		} else {
-			if def.EndOfLine != "" && def.EndOfLine != UnsetValue {
+			if def.EndOfLine != "" && def.EndOfLine != UnsetValue && enabled(RuleEndOfLine) {
 				err = endOfLine(def.EndOfLine, data)
 			}
 		}
@@ -163,6 +227,13 @@ func validate( //nolint:cyclop,gocognit,funlen
 				}
 			}
 
+			// The indentation is always checked to keep track of the block
+			// comments, the disabled or suppressed rules are dropped afterwards.
+			var ve ValidationError
+			if ok := errors.As(err, &ve); ok && !enabled(ve.Rule) {
+				err = nil
+			}
+
 			if def.InsideBlockComment && def.BlockCommentEnd != nil {
 				def.InsideBlockComment = !isBlockCommentEnd(def.BlockCommentEnd, data)
 			}
@@ -172,11 +243,14 @@ func validate( //nolint:cyclop,gocognit,funlen
 			}
 		}
 
-		if err == nil && def.TrimTrailingWhitespace != nil && *def.TrimTrailingWhitespace {
+		if err == nil &&
+			def.TrimTrailingWhitespace != nil &&
+			*def.TrimTrailingWhitespace &&
+			enabled(RuleTrimTrailingWhitespace) {
 			err = checkTrimTrailingWhitespace(data)
 		}
 
-		if err == nil && def.MaxLength > 0 {
+		if err == nil && def.MaxLength > 0 && enabled(RuleMaxLineLength) {
 			// Remove any BOM from the first line.
 			d := data
 			if index == 0 && charset != "" {
@@ -191,11 +265,12 @@ func validate( //nolint:cyclop,gocognit,funlen
 			err = MaxLineLength(def.MaxLength, def.TabWidth, d)
 		}
 
-		// Enrich the error with the line number
+		// Enrich the error with the line number and its severity
 		var ve ValidationError
 		if ok := errors.As(err, &ve); ok {
 			ve.Line = data
 			ve.Index = index
+			ve.Severity = rules.Severity(ve.Rule)
 
 			return ve
 		}
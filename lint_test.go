@@ -3,6 +3,7 @@ package eclint
 import (
 	"bytes"
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/editorconfig/editorconfig-core-go/v2"
@@ -173,3 +174,38 @@ func TestBlockCommentFailure(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRuleSet(t *testing.T) {
+	file := []byte("A line with trailing whitespaces  \n\tAn indented line\n")
+	trim := true
+
+	def, err := newDefinition(&editorconfig.Definition{
+		IndentStyle:            "space",
+		IndentSize:             "2",
+		TrimTrailingWhitespace: &trim,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := NewRuleSet([]string{RuleIndentStyle}, []string{RuleTrimTrailingWhitespace})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def.rules = rules
+
+	errs := validate(context.TODO(), bytes.NewReader(file), -1, "utf-8", def)
+	if len(errs) != 1 {
+		t.Fatalf("one error was expected, got %d", len(errs))
+	}
+
+	var ve ValidationError
+	if ok := errors.As(errs[0], &ve); !ok {
+		t.Fatalf("a ValidationError was expected, got %v", errs[0])
+	}
+
+	if ve.Rule != RuleTrimTrailingWhitespace || ve.Severity != SeverityWarning {
+		t.Errorf("a trim_trailing_whitespace warning was expected, got %s %s", ve.Severity, ve.Rule)
+	}
+}
@@ -7,13 +7,34 @@ import (
 // Option contains the environment of the program.
 //
 // When ShowErrorQuantity is 0, it will show all the errors. Use ShowAllErrors false to disable this.
+//
+// Exclude is a single pattern, it's checked along the Excludes ones.
+//
+// Rules are the enabled rules and their severity, nil enables them all.
 type Option struct {
 	IsTerminal        bool
 	NoColors          bool
 	ShowAllErrors     bool
 	Summary           bool
 	FixAllErrors      bool
+	DryRun            bool
+	Uncommitted       bool
+	DiffLinesOnly     bool
+	Staged            bool
+	Untracked         bool
+	NulSeparated      bool
 	ShowErrorQuantity int
+	Jobs              int
+	Format            string
+	Baseline          string
+	WriteBaseline     string
+	BackupSuffix      string
+	StdinFilename     string
+	ChangedSince      string
+	FilesFrom         string
 	Exclude           string
+	Excludes          []string
+	Includes          []string
+	Rules             *RuleSet
 	Stdout            io.Writer
 }
@@ -0,0 +1,43 @@
+package eclint
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+)
+
+// CachedConfig loads the definitions while caching the parsed .editorconfig
+// files and globbing patterns.
+//
+// Unlike an editorconfig.Config using an editorconfig.CachedParser, it is
+// safe for concurrent use.
+type CachedConfig struct {
+	mu     sync.Mutex
+	config *editorconfig.Config
+}
+
+// NewCachedConfig initializes the CachedConfig.
+func NewCachedConfig() *CachedConfig {
+	return &CachedConfig{
+		config: &editorconfig.Config{
+			Parser: editorconfig.NewCachedParser(),
+		},
+	}
+}
+
+// Load returns the definition of the given file.
+//
+// The loading is serialized as both the cache and the parsed files are
+// shared.
+func (c *CachedConfig) Load(filename string) (*editorconfig.Definition, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	def, err := c.config.Load(filename)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the definition of %s. %w", filename, err)
+	}
+
+	return def, nil
+}
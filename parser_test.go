@@ -0,0 +1,35 @@
+package eclint_test
+
+import (
+	"sync"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+func TestCachedConfig(t *testing.T) {
+	config := eclint.NewCachedConfig()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			def, err := config.Load("testdata/simple/simple.txt")
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			if def.IndentStyle != "tab" {
+				t.Errorf("a tab indent_style was expected, got %q", def.IndentStyle)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -5,7 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/logrusorgru/aurora"
@@ -33,7 +37,13 @@ func PrintErrors(ctx context.Context, opt *Option, filename string, errs []error
 				if !opt.Summary {
 					vi := au.Green(strconv.Itoa(ve.Index + 1)).Bold()
 					vp := au.Green(strconv.Itoa(ve.Position + 1)).Bold()
-					fmt.Fprintf(stdout, "%s:%s: %s\n", vi, vp, ve.Message)
+					fmt.Fprintf(stdout, "%s:%s: %s: %s", vi, vp, severityAt(au, ve.severity()), ve.Message)
+
+					if ve.Rule != "" {
+						fmt.Fprintf(stdout, " [%s]", au.Cyan(ve.Rule))
+					}
+
+					fmt.Fprintln(stdout)
 
 					l, err := errorAt(au, ve.Line, ve.Position)
 					if err != nil {
@@ -67,13 +77,99 @@ func PrintErrors(ctx context.Context, opt *Option, filename string, errs []error
 		if !opt.Summary {
 			fmt.Fprintln(stdout, "")
 		} else {
-			fmt.Fprintf(stdout, "%s: %d errors\n", au.Magenta(filename), counter)
+			fmt.Fprintf(stdout, "%s: %d errors, %d warnings\n", au.Magenta(filename), CountErrors(errs), countWarnings(errs))
+		}
+	}
+
+	return nil
+}
+
+// countWarnings counts the validation errors having the warning severity.
+func countWarnings(errs []error) int {
+	c := 0
+
+	for _, err := range errs {
+		var ve ValidationError
+		if ok := errors.As(err, &ve); ok && ve.severity() == SeverityWarning {
+			c++
+		}
+	}
+
+	return c
+}
+
+// PrintFixes shows the changes made to a file.
+func PrintFixes(_ context.Context, opt *Option, report *FixReport) error {
+	if report == nil || len(report.Changes) == 0 {
+		return nil
+	}
+
+	stdout := opt.Stdout
+	au := aurora.NewAurora(opt.IsTerminal && !opt.NoColors)
+
+	if opt.Summary {
+		lines := 0
+		for _, n := range report.Totals() {
+			lines += n
+		}
+
+		_, err := fmt.Fprintf(stdout, "%s: %d lines fixed\n", au.Magenta(report.Filename), lines)
+		if err != nil {
+			return fmt.Errorf("cannot print the fixes: %w", err)
+		}
+
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "%s:\n", au.Magenta(report.Filename).Bold())
+
+	for _, c := range report.Changes {
+		lines := strconv.Itoa(c.Start + 1)
+		if c.End != c.Start {
+			lines += "-" + strconv.Itoa(c.End+1)
 		}
+
+		fmt.Fprintf(stdout, "%s: fixed [%s]\n", au.Green(lines).Bold(), au.Cyan(c.Rule))
+	}
+
+	if _, err := fmt.Fprintln(stdout, ""); err != nil {
+		return fmt.Errorf("cannot print the fixes: %w", err)
 	}
 
 	return nil
 }
 
+// printFixTotals shows the number of fixed files and lines per rule.
+func printFixTotals(opt *Option, files int, totals map[string]int) error {
+	au := aurora.NewAurora(opt.IsTerminal && !opt.NoColors)
+
+	rules := make([]string, 0, len(totals))
+	for rule := range totals {
+		rules = append(rules, rule)
+	}
+
+	sort.Strings(rules)
+
+	fmt.Fprintf(opt.Stdout, "%s files fixed\n", au.Bold(strconv.Itoa(files)))
+
+	for _, rule := range rules {
+		if _, err := fmt.Fprintf(opt.Stdout, "%s: %d lines\n", au.Cyan(rule), totals[rule]); err != nil {
+			return fmt.Errorf("cannot print the fixes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// severityAt colors the severity.
+func severityAt(au aurora.Aurora, severity Severity) aurora.Value {
+	if severity == SeverityWarning {
+		return au.Yellow(severity)
+	}
+
+	return au.Red(severity)
+}
+
 // errorAt highlights the ValidationError position within the line.
 func errorAt(au aurora.Aurora, line []byte, position int) (string, error) {
 	b := bytes.NewBuffer(make([]byte, len(line)))
@@ -122,3 +218,118 @@ func errorAt(au aurora.Aurora, line []byte, position int) (string, error) {
 
 	return b.String(), nil
 }
+
+// PrintExplanation shows where the definition of a file comes from.
+func PrintExplanation(_ context.Context, opt *Option, e *Explanation) error {
+	stdout := opt.Stdout
+	au := aurora.NewAurora(opt.IsTerminal && !opt.NoColors)
+
+	fmt.Fprintf(stdout, "%s:\n", au.Magenta(e.Filename).Bold())
+
+	fmt.Fprintln(stdout, au.Bold("files:"))
+
+	if len(e.Files) == 0 {
+		fmt.Fprintln(stdout, "  none")
+	}
+
+	for _, f := range e.Files {
+		if f == e.Root {
+			fmt.Fprintf(stdout, "  %s %s\n", displayPath(f), au.Cyan("(root)"))
+		} else {
+			fmt.Fprintf(stdout, "  %s\n", displayPath(f))
+		}
+	}
+
+	fmt.Fprintln(stdout, au.Bold("sections:"))
+
+	for _, s := range e.Sections {
+		fmt.Fprintf(stdout, "  %s:%d [%s]\n", displayPath(s.Filename), s.Line, au.Cyan(s.Glob))
+	}
+
+	fmt.Fprintln(stdout, au.Bold("properties:"))
+
+	for _, p := range e.Properties {
+		if len(p.Values) == 0 {
+			fmt.Fprintf(stdout, "  %s = %s %s\n", p.Key, au.Green(p.Value), au.Faint("(derived)"))
+
+			continue
+		}
+
+		fmt.Fprintf(stdout, "  %s = %s\n", p.Key, au.Green(p.Value))
+
+		// By decreasing precedence, the first one wins.
+		for i := len(p.Values) - 1; i >= 0; i-- {
+			v := p.Values[i]
+			fmt.Fprintf(stdout, "    %s from %s:%d [%s]", v.Value, displayPath(v.Section.Filename), v.Line, v.Section.Glob)
+
+			if i < len(p.Values)-1 {
+				fmt.Fprintf(stdout, " %s", au.Faint("(overridden)"))
+			}
+
+			fmt.Fprintln(stdout)
+		}
+	}
+
+	if len(e.Overrides) > 0 {
+		fmt.Fprintln(stdout, au.Bold("overrides:"))
+
+		for _, o := range e.Overrides {
+			previous := o.Previous
+			if previous == "" {
+				previous = "none"
+			}
+
+			fmt.Fprintf(stdout, "  %s = %s instead of %s\n", o.Key, au.Green(o.Value), previous)
+		}
+	}
+
+	fmt.Fprintln(stdout, au.Bold("definition:"))
+
+	if e.Err != nil {
+		fmt.Fprintf(stdout, "  %s\n", au.Red(e.Err))
+	} else {
+		d := e.Definition
+
+		maxLength := "off"
+		if d.MaxLength > 0 {
+			maxLength = strconv.Itoa(d.MaxLength)
+		}
+
+		fmt.Fprintf(stdout, "  indent size: %d\n", d.IndentSize)
+		fmt.Fprintf(stdout, "  tab width: %d\n", d.TabWidth)
+		fmt.Fprintf(stdout, "  max length: %s\n", maxLength)
+		fmt.Fprintf(stdout, "  block comment start: %s\n", noneIfEmpty(d.BlockCommentStart))
+		fmt.Fprintf(stdout, "  block comment: %s\n", noneIfEmpty(d.BlockComment))
+		fmt.Fprintf(stdout, "  block comment end: %s\n", noneIfEmpty(d.BlockCommentEnd))
+	}
+
+	if _, err := fmt.Fprintln(stdout, ""); err != nil {
+		return fmt.Errorf("cannot print the explanation: %w", err)
+	}
+
+	return nil
+}
+
+// displayPath shortens the path when it's within the current directory.
+func displayPath(filename string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return filename
+	}
+
+	rel, err := filepath.Rel(wd, filename)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filename
+	}
+
+	return rel
+}
+
+// noneIfEmpty shows the missing values.
+func noneIfEmpty(value string) string {
+	if value == "" {
+		return "none"
+	}
+
+	return value
+}
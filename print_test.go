@@ -67,3 +67,50 @@ func TestPrintErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintErrorsSummary(t *testing.T) {
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+	opt := &eclint.Option{Stdout: buf, Summary: true}
+	errs := []error{
+		eclint.ValidationError{Severity: eclint.SeverityError},
+		eclint.ValidationError{Severity: eclint.SeverityWarning},
+		errors.New("random error"),
+	}
+
+	if err := eclint.PrintErrors(context.TODO(), opt, "a.txt", errs); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "random error\na.txt: 2 errors, 1 warnings\n"
+	if buf.String() != expected {
+		t.Errorf("%q was expected, got %q", expected, buf.String())
+	}
+}
+
+func TestPrintFixes(t *testing.T) {
+	ctx := context.TODO()
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+	opt := &eclint.Option{Stdout: buf}
+
+	err := eclint.PrintFixes(ctx, opt, &eclint.FixReport{
+		Filename: "a.txt",
+		Changes: []eclint.FixChange{
+			{Rule: eclint.RuleIndentStyle, Start: 0, End: 2},
+			{Rule: eclint.RuleTrimTrailingWhitespace, Start: 4, End: 4},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "a.txt:\n1-3: fixed [indent_style]\n5: fixed [trim_trailing_whitespace]\n\n"
+	if buf.String() != expected {
+		t.Errorf("%q was expected, got %q", expected, buf.String())
+	}
+
+	buf.Reset()
+
+	if err := eclint.PrintFixes(ctx, opt, nil); err != nil || buf.Len() != 0 {
+		t.Errorf("no output was expected, got %q, %v", buf.String(), err)
+	}
+}
@@ -119,7 +119,9 @@ func probeCharset(ctx context.Context, bs []byte, charset string) (string, error
 
 	if charset != "" && cs != "" && cs != charset {
 		return "", ValidationError{
-			Message: fmt.Sprintf("no %s prefix were found, got %q", charset, cs),
+			Rule:     RuleCharset,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("no %s prefix were found, got %q", charset, cs),
 		}
 	}
 
@@ -134,7 +136,9 @@ func probeCharset(ctx context.Context, bs []byte, charset string) (string, error
 		// latin1 is a strict subset of utf-8
 		if charset != cs {
 			return "", ValidationError{
-				Message: fmt.Sprintf("detected charset %q does not match expected %q", cs, charset),
+				Rule:     RuleCharset,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("detected charset %q does not match expected %q", cs, charset),
 			}
 		}
 
@@ -0,0 +1,140 @@
+package eclint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownFormat is returned when the output format is not supported.
+var ErrUnknownFormat = errors.New("unknown output format")
+
+const (
+	// FormatText is the rich, and colored, human output.
+	FormatText = "text"
+	// FormatJSON is a JSON array of the errors.
+	FormatJSON = "json"
+	// FormatSARIF is a SARIF 2.1.0 log, as used by code-scanning tools.
+	FormatSARIF = "sarif"
+	// FormatCheckstyle is the Checkstyle XML format.
+	FormatCheckstyle = "checkstyle"
+	// FormatJUnit is the JUnit XML format, with one testcase per file.
+	FormatJUnit = "junit"
+	// FormatGitHub are the GitHub Actions workflow commands.
+	FormatGitHub = "github"
+	// FormatGitLab is the GitLab Code Quality report.
+	FormatGitLab = "gitlab"
+)
+
+// Formats lists the supported output formats.
+func Formats() []string {
+	return []string{
+		FormatText,
+		FormatJSON,
+		FormatSARIF,
+		FormatCheckstyle,
+		FormatJUnit,
+		FormatGitHub,
+		FormatGitLab,
+	}
+}
+
+// DetectFormat picks the output format based on the CI environment.
+//
+// GitHub Actions and GitLab CI are recognized, otherwise it's the text one.
+func DetectFormat(getenv func(string) string) string {
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		return FormatGitHub
+	case getenv("GITLAB_CI") == "true":
+		return FormatGitLab
+	default:
+		return FormatText
+	}
+}
+
+// Reporter renders the errors found while linting the files.
+//
+// Report is called once per file, in the order they were listed. Close
+// is called at the end of the run and gives a chance to the formats that
+// build a single document to write it.
+type Reporter interface {
+	Report(ctx context.Context, filename string, errs []error) error
+	Close(ctx context.Context) error
+}
+
+// NewReporter builds the reporter matching the Option's Format.
+func NewReporter(opt *Option) (Reporter, error) {
+	return newReporter(opt, Rules())
+}
+
+// NewConfigReporter builds the reporter of the checks of the .editorconfig
+// files, see ConfigRules.
+func NewConfigReporter(opt *Option) (Reporter, error) {
+	return newReporter(opt, ConfigRules())
+}
+
+// newReporter builds the reporter, the rules are the ones the formats
+// describing them list.
+func newReporter(opt *Option, rules []Rule) (Reporter, error) {
+	switch opt.Format {
+	case "", FormatText:
+		return &textReporter{opt: opt}, nil
+	case FormatJSON:
+		return &jsonReporter{w: opt.Stdout}, nil
+	case FormatSARIF:
+		return &sarifReporter{w: opt.Stdout, rules: rules}, nil
+	case FormatCheckstyle:
+		return &checkstyleReporter{w: opt.Stdout}, nil
+	case FormatJUnit:
+		return &junitReporter{w: opt.Stdout}, nil
+	case FormatGitHub:
+		return &githubReporter{w: opt.Stdout}, nil
+	case FormatGitLab:
+		return &gitlabReporter{w: opt.Stdout}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q, want one of %q", ErrUnknownFormat, opt.Format, Formats())
+	}
+}
+
+// lineContent returns the line without its line ending.
+func lineContent(line []byte) string {
+	return string(bytes.TrimRight(line, "\r\n"))
+}
+
+// textReporter relies on PrintErrors.
+type textReporter struct {
+	opt    *Option
+	files  int
+	totals map[string]int
+}
+
+// Report prints the errors of the given file.
+func (r *textReporter) Report(ctx context.Context, filename string, errs []error) error {
+	return PrintErrors(ctx, r.opt, filename, errs)
+}
+
+// ReportFixes prints the changes made to the given file.
+func (r *textReporter) ReportFixes(ctx context.Context, report *FixReport) error {
+	if r.totals == nil {
+		r.totals = make(map[string]int)
+	}
+
+	r.files++
+
+	for rule, n := range report.Totals() {
+		r.totals[rule] += n
+	}
+
+	return PrintFixes(ctx, r.opt, report)
+}
+
+// Close prints the totals of the fixes, if any.
+func (r *textReporter) Close(_ context.Context) error {
+	if r.files == 0 {
+		return nil
+	}
+
+	return printFixTotals(r.opt, r.files, r.totals)
+}
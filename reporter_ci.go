@@ -0,0 +1,198 @@
+package eclint
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// githubReporter emits GitHub Actions workflow commands.
+//
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+type githubReporter struct {
+	w io.Writer
+}
+
+// Report prints one ::error, or ::warning, command per error.
+func (r *githubReporter) Report(_ context.Context, filename string, errs []error) error {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var line string
+
+		var ve ValidationError
+		if ok := errors.As(err, &ve); ok {
+			fn := ve.Filename
+			if fn == "" {
+				fn = filename
+			}
+
+			props := []string{
+				"file=" + githubEscapeProperty(filepath.ToSlash(fn)),
+				fmt.Sprintf("line=%d", ve.Index+1),
+				fmt.Sprintf("col=%d", ve.Position+1),
+			}
+
+			if ve.Rule != "" {
+				props = append(props, "title="+githubEscapeProperty(ve.Rule))
+			}
+
+			line = fmt.Sprintf(
+				"::%s %s::%s\n",
+				ve.severity(),
+				strings.Join(props, ","),
+				githubEscapeData(ve.Message),
+			)
+		} else {
+			line = fmt.Sprintf(
+				"::error file=%s::%s\n",
+				githubEscapeProperty(filepath.ToSlash(filename)),
+				githubEscapeData(err.Error()),
+			)
+		}
+
+		if _, err := io.WriteString(r.w, line); err != nil {
+			return fmt.Errorf("cannot write workflow command: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close does nothing as everything was already printed.
+func (r *githubReporter) Close(_ context.Context) error {
+	return nil
+}
+
+// githubEscapeData escapes the message of a workflow command.
+func githubEscapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// githubEscapeProperty escapes the property value of a workflow command.
+func githubEscapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// gitlabReporter builds a GitLab Code Quality report.
+//
+// See https://docs.gitlab.com/ee/ci/testing/code_quality.html
+type gitlabReporter struct {
+	w      io.Writer
+	issues []gitlabIssue
+	seen   map[string]int
+}
+
+// Report collects the errors as code quality issues.
+func (r *gitlabReporter) Report(_ context.Context, filename string, errs []error) error {
+	if r.seen == nil {
+		r.seen = make(map[string]int)
+	}
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		issue := gitlabIssue{
+			CheckName: toolName,
+			Severity:  "major",
+			Location: gitlabLocation{
+				Path:  filepath.ToSlash(filename),
+				Lines: gitlabLines{Begin: 1},
+			},
+		}
+
+		content := ""
+
+		var ve ValidationError
+		if ok := errors.As(err, &ve); ok {
+			if ve.Filename != "" {
+				issue.Location.Path = filepath.ToSlash(ve.Filename)
+			}
+
+			if ve.Rule != "" {
+				issue.CheckName = ve.Rule
+			}
+
+			issue.Description = ve.Message
+			issue.Severity = gitlabSeverity(ve.severity())
+			issue.Location.Lines.Begin = ve.Index + 1
+			content = lineContent(ve.Line)
+		} else {
+			issue.Description = err.Error()
+			content = err.Error()
+		}
+
+		issue.Fingerprint = r.fingerprint(issue.Location.Path, issue.CheckName, content)
+		r.issues = append(r.issues, issue)
+	}
+
+	return nil
+}
+
+// gitlabSeverity maps the severity to the code quality ones.
+func gitlabSeverity(severity Severity) string {
+	if severity == SeverityWarning {
+		return "minor"
+	}
+
+	return "major"
+}
+
+// fingerprint computes a stable identifier from the file, the rule and the
+// line content.
+//
+// The line number is left out so that adding lines above doesn't change it,
+// identical lines are told apart using their occurrence.
+func (r *gitlabReporter) fingerprint(path, rule, content string) string {
+	key := strings.Join([]string{path, rule, content}, "\x00")
+	n := r.seen[key]
+	r.seen[key] = n + 1
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, n)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Close writes the JSON document.
+func (r *gitlabReporter) Close(_ context.Context) error {
+	issues := r.issues
+	if issues == nil {
+		issues = []gitlabIssue{}
+	}
+
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(issues); err != nil {
+		return fmt.Errorf("cannot encode code quality report: %w", err)
+	}
+
+	return nil
+}
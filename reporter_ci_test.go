@@ -0,0 +1,145 @@
+package eclint_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Env    map[string]string
+		Format string
+	}{
+		{
+			Name:   "nothing",
+			Env:    map[string]string{},
+			Format: eclint.FormatText,
+		}, {
+			Name:   "github",
+			Env:    map[string]string{"GITHUB_ACTIONS": "true"},
+			Format: eclint.FormatGitHub,
+		}, {
+			Name:   "gitlab",
+			Env:    map[string]string{"GITLAB_CI": "true"},
+			Format: eclint.FormatGitLab,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			format := eclint.DetectFormat(func(key string) string {
+				return tc.Env[key]
+			})
+			if format != tc.Format {
+				t.Errorf("format mismatch %q, got %q", tc.Format, format)
+			}
+		})
+	}
+}
+
+func TestGitHubReporter(t *testing.T) {
+	ctx := context.TODO()
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+
+	r, err := eclint.NewReporter(&eclint.Option{Format: eclint.FormatGitHub, Stdout: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.Report(ctx, "a,b.txt", []error{
+		eclint.ValidationError{
+			Rule:     eclint.RuleMaxLineLength,
+			Message:  "line is too long (100% > 80)",
+			Index:    2,
+			Position: 80,
+		},
+		eclint.ValidationError{
+			Rule:     eclint.RuleTrimTrailingWhitespace,
+			Severity: eclint.SeverityWarning,
+			Message:  "line has some trailing whitespaces",
+			Index:    3,
+			Position: 4,
+		},
+		errors.New("random\nerror"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "::error file=a%2Cb.txt,line=3,col=81,title=max_line_length::line is too long (100%25 > 80)\n" +
+		"::warning file=a%2Cb.txt,line=4,col=5,title=trim_trailing_whitespace::line has some trailing whitespaces\n" +
+		"::error file=a%2Cb.txt::random%0Aerror\n"
+	if buf.String() != expected {
+		t.Errorf("output mismatch %q, got %q", expected, buf.String())
+	}
+}
+
+func TestGitLabReporter(t *testing.T) {
+	ctx := context.TODO()
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+
+	r, err := eclint.NewReporter(&eclint.Option{Format: eclint.FormatGitLab, Stdout: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ve := eclint.ValidationError{
+		Rule:     eclint.RuleTrimTrailingWhitespace,
+		Message:  "line has some trailing whitespaces",
+		Line:     []byte("Hello \n"),
+		Index:    1,
+		Position: 5,
+	}
+
+	moved := ve
+	moved.Index = 4
+
+	if err := r.Report(ctx, "a.txt", []error{ve, moved}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var issues []struct {
+		CheckName   string `json:"check_name"`
+		Fingerprint string `json:"fingerprint"`
+		Location    struct {
+			Path  string `json:"path"`
+			Lines struct {
+				Begin int `json:"begin"`
+			} `json:"lines"`
+		} `json:"location"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("cannot decode %q: %s", buf.String(), err)
+	}
+
+	if len(issues) != 2 {
+		t.Fatalf("two issues were expected, got %d", len(issues))
+	}
+
+	if issues[0].CheckName != eclint.RuleTrimTrailingWhitespace || issues[0].Location.Lines.Begin != 2 {
+		t.Errorf("unexpected issue, got %+v", issues[0])
+	}
+
+	if issues[0].Fingerprint == issues[1].Fingerprint {
+		t.Error("identical lines should get distinct fingerprints")
+	}
+}
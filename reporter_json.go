@@ -0,0 +1,110 @@
+package eclint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	jsonTypeValidation = "validation"
+	jsonTypeError      = "error"
+	jsonTypeFix        = "fix"
+)
+
+// jsonError is the serialized form of an error, or of a fix.
+//
+// Line and Column are 1-based, like in the text output. The fixes span
+// from Line to EndLine.
+type jsonError struct {
+	Type     string `json:"type"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line,omitempty"`
+	EndLine  int    `json:"end_line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Message  string `json:"message"`
+	Content  string `json:"content,omitempty"`
+}
+
+// jsonReporter collects all the errors, and fixes, and writes them as a single
+// JSON array.
+type jsonReporter struct {
+	w    io.Writer
+	errs []jsonError
+}
+
+// Report collects the errors of the given file.
+func (r *jsonReporter) Report(_ context.Context, filename string, errs []error) error {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var ve ValidationError
+		if ok := errors.As(err, &ve); ok {
+			fn := ve.Filename
+			if fn == "" {
+				fn = filename
+			}
+
+			r.errs = append(r.errs, jsonError{
+				Type:     jsonTypeValidation,
+				Filename: fn,
+				Line:     ve.Index + 1,
+				Column:   ve.Position + 1,
+				Rule:     ve.Rule,
+				Severity: string(ve.severity()),
+				Message:  ve.Message,
+				Content:  lineContent(ve.Line),
+			})
+
+			continue
+		}
+
+		r.errs = append(r.errs, jsonError{
+			Type:     jsonTypeError,
+			Filename: filename,
+			Severity: string(SeverityError),
+			Message:  err.Error(),
+		})
+	}
+
+	return nil
+}
+
+// ReportFixes collects the changes made to the given file.
+func (r *jsonReporter) ReportFixes(_ context.Context, report *FixReport) error {
+	for _, c := range report.Changes {
+		r.errs = append(r.errs, jsonError{
+			Type:     jsonTypeFix,
+			Filename: report.Filename,
+			Line:     c.Start + 1,
+			EndLine:  c.End + 1,
+			Rule:     c.Rule,
+			Message:  "fixed",
+		})
+	}
+
+	return nil
+}
+
+// Close writes the JSON document.
+func (r *jsonReporter) Close(_ context.Context) error {
+	errs := r.errs
+	if errs == nil {
+		errs = []jsonError{}
+	}
+
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(errs); err != nil {
+		return fmt.Errorf("cannot encode JSON: %w", err)
+	}
+
+	return nil
+}
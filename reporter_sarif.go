@@ -0,0 +1,205 @@
+package eclint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "eclint"
+	toolURI      = "https://gitlab.com/greut/eclint"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn"`
+	Snippet     *sarifMessage `json:"snippet,omitempty"`
+}
+
+// sarifReporter builds a SARIF log with a single run.
+type sarifReporter struct {
+	w             io.Writer
+	rules         []Rule
+	results       []sarifResult
+	notifications []sarifNotification
+}
+
+// Report converts the errors of the given file into SARIF results.
+//
+// The errors that aren't validation errors become notifications of the
+// tool execution.
+func (r *sarifReporter) Report(_ context.Context, filename string, errs []error) error {
+	ruleIndexes := make(map[string]int)
+	for i, rule := range r.rules {
+		ruleIndexes[rule.ID] = i
+	}
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var ve ValidationError
+		if ok := errors.As(err, &ve); !ok {
+			r.notifications = append(r.notifications, sarifNotification{
+				Level:   "error",
+				Message: sarifMessage{Text: err.Error()},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(filename)},
+					},
+				}},
+			})
+
+			continue
+		}
+
+		fn := ve.Filename
+		if fn == "" {
+			fn = filename
+		}
+
+		region := &sarifRegion{
+			StartLine:   ve.Index + 1,
+			StartColumn: ve.Position + 1,
+		}
+
+		if content := lineContent(ve.Line); content != "" {
+			region.Snippet = &sarifMessage{Text: content}
+		}
+
+		result := sarifResult{
+			RuleID:  ve.Rule,
+			Level:   string(ve.severity()),
+			Message: sarifMessage{Text: ve.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(fn)},
+					Region:           region,
+				},
+			}},
+		}
+
+		if i, ok := ruleIndexes[ve.Rule]; ok {
+			i := i
+			result.RuleIndex = &i
+		}
+
+		r.results = append(r.results, result)
+	}
+
+	return nil
+}
+
+// Close writes the SARIF log.
+func (r *sarifReporter) Close(_ context.Context) error {
+	rules := make([]sarifRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, sarifRule{
+			ID:               rule.ID,
+			ShortDescription: sarifMessage{Text: rule.Description},
+		})
+	}
+
+	results := r.results
+	if results == nil {
+		results = []sarifResult{}
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           toolName,
+					InformationURI: toolURI,
+					Rules:          rules,
+				},
+			},
+			Invocations: []sarifInvocation{{
+				ExecutionSuccessful:        len(r.notifications) == 0,
+				ToolExecutionNotifications: r.notifications,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("cannot encode SARIF: %w", err)
+	}
+
+	return nil
+}
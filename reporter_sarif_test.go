@@ -0,0 +1,101 @@
+package eclint_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+func TestSARIFReporter(t *testing.T) {
+	ctx := context.TODO()
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+
+	r, err := eclint.NewReporter(&eclint.Option{Format: eclint.FormatSARIF, Stdout: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.Report(ctx, "dir/a.txt", []error{
+		eclint.ValidationError{
+			Rule:     eclint.RuleMaxLineLength,
+			Message:  "line is too long (5 > 4)",
+			Line:     []byte("Hello\n"),
+			Index:    2,
+			Position: 4,
+		},
+		errors.New("random error"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Invocations []struct {
+				ExecutionSuccessful bool `json:"executionSuccessful"`
+			} `json:"invocations"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("cannot decode %q: %s", buf.String(), err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("one SARIF 2.1.0 run was expected, got %q", buf.String())
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(eclint.Rules()) {
+		t.Errorf("all the rules were expected, got %d", len(run.Tool.Driver.Rules))
+	}
+
+	if run.Invocations[0].ExecutionSuccessful {
+		t.Error("the execution should not be successful")
+	}
+
+	if len(run.Results) != 1 {
+		t.Fatalf("one result was expected, got %d", len(run.Results))
+	}
+
+	result := run.Results[0]
+	if run.Tool.Driver.Rules[result.RuleIndex].ID != result.RuleID {
+		t.Errorf("rule index mismatch, got %d for %q", result.RuleIndex, result.RuleID)
+	}
+
+	loc := result.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "dir/a.txt" || loc.Region.StartLine != 3 || loc.Region.StartColumn != 5 {
+		t.Errorf("unexpected location, got %+v", loc)
+	}
+}
@@ -0,0 +1,142 @@
+package eclint_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+func TestNewReporter(t *testing.T) {
+	for _, format := range eclint.Formats() {
+		opt := &eclint.Option{
+			Format: format,
+			Stdout: &bytes.Buffer{},
+		}
+
+		if _, err := eclint.NewReporter(opt); err != nil {
+			t.Errorf("format %q: no errors were expected, got %s", format, err)
+		}
+	}
+
+	_, err := eclint.NewReporter(&eclint.Option{Format: "xls"})
+	if !errors.Is(err, eclint.ErrUnknownFormat) {
+		t.Errorf("an unknown format error was expected, got %v", err)
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	ctx := context.TODO()
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+	opt := &eclint.Option{
+		Format: eclint.FormatJSON,
+		Stdout: buf,
+	}
+
+	r, err := eclint.NewReporter(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.Report(ctx, "a.txt", []error{
+		eclint.ValidationError{
+			Message:  "line has some trailing whitespaces",
+			Filename: "a.txt",
+			Line:     []byte("Hello \r\n"),
+			Index:    1,
+			Position: 5,
+		},
+		errors.New("random error"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("cannot decode %q: %s", buf.String(), err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("two errors were expected, got %d", len(result))
+	}
+
+	ve := result[0]
+	if ve["severity"] != "error" {
+		t.Errorf("an error severity was expected, got %v", ve["severity"])
+	}
+
+	if ve["type"] != "validation" || ve["line"] != 2.0 || ve["column"] != 6.0 || ve["content"] != "Hello " {
+		t.Errorf("unexpected validation error, got %v", ve)
+	}
+
+	if result[1]["type"] != "error" || result[1]["filename"] != "a.txt" {
+		t.Errorf("unexpected error, got %v", result[1])
+	}
+}
+
+func TestJSONReporterEmpty(t *testing.T) {
+	ctx := context.TODO()
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+
+	r, err := eclint.NewReporter(&eclint.Option{Format: eclint.FormatJSON, Stdout: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "[]\n" {
+		t.Errorf("an empty array was expected, got %q", buf.String())
+	}
+}
+
+func TestJSONReporterFixes(t *testing.T) {
+	ctx := context.TODO()
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+
+	r, err := eclint.NewReporter(&eclint.Option{Format: eclint.FormatJSON, Stdout: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fr, ok := r.(eclint.FixReporter)
+	if !ok {
+		t.Fatal("the JSON reporter was expected to report the fixes")
+	}
+
+	err = fr.ReportFixes(ctx, &eclint.FixReport{
+		Filename: "a.txt",
+		Changes:  []eclint.FixChange{{Rule: eclint.RuleIndentStyle, Start: 0, End: 2}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var result []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("cannot decode %q: %s", buf.String(), err)
+	}
+
+	if len(result) != 1 {
+		t.Fatalf("one fix was expected, got %d", len(result))
+	}
+
+	fix := result[0]
+	if fix["type"] != "fix" || fix["line"] != 1.0 || fix["end_line"] != 3.0 || fix["rule"] != "indent_style" {
+		t.Errorf("unexpected fix, got %v", fix)
+	}
+}
@@ -0,0 +1,184 @@
+package eclint
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const checkstyleVersion = "4.3"
+
+type checkstyleLog struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// checkstyleReporter builds a Checkstyle XML document.
+type checkstyleReporter struct {
+	w     io.Writer
+	files []checkstyleFile
+}
+
+// Report adds one file element with all its errors.
+func (r *checkstyleReporter) Report(_ context.Context, filename string, errs []error) error {
+	file := checkstyleFile{
+		Name: filename,
+	}
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var ve ValidationError
+		if ok := errors.As(err, &ve); !ok {
+			file.Errors = append(file.Errors, checkstyleError{
+				Severity: "error",
+				Message:  err.Error(),
+				Source:   toolName,
+			})
+
+			continue
+		}
+
+		source := toolName
+		if ve.Rule != "" {
+			source = toolName + "." + ve.Rule
+		}
+
+		file.Errors = append(file.Errors, checkstyleError{
+			Line:     ve.Index + 1,
+			Column:   ve.Position + 1,
+			Severity: string(ve.severity()),
+			Message:  ve.Message,
+			Source:   source,
+		})
+	}
+
+	r.files = append(r.files, file)
+
+	return nil
+}
+
+// Close writes the Checkstyle document.
+func (r *checkstyleReporter) Close(_ context.Context) error {
+	return writeXML(r.w, checkstyleLog{
+		Version: checkstyleVersion,
+		Files:   r.files,
+	})
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+	Errors    []junitFailure `xml:"error"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// junitReporter builds a JUnit XML report, with one testcase per file.
+type junitReporter struct {
+	w     io.Writer
+	suite junitTestSuite
+}
+
+// Report adds one testcase for the given file, each validation error
+// becomes a failure, the other errors are errors.
+func (r *junitReporter) Report(_ context.Context, filename string, errs []error) error {
+	tc := junitTestCase{
+		Name:      filename,
+		ClassName: toolName,
+	}
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var ve ValidationError
+		if ok := errors.As(err, &ve); !ok {
+			tc.Errors = append(tc.Errors, junitFailure{
+				Message: err.Error(),
+				Text:    err.Error(),
+			})
+
+			continue
+		}
+
+		tc.Failures = append(tc.Failures, junitFailure{
+			Message: fmt.Sprintf("%d:%d: %s: %s", ve.Index+1, ve.Position+1, ve.severity(), ve.Message),
+			Type:    ve.Rule,
+			Text:    lineContent(ve.Line),
+		})
+	}
+
+	r.suite.Tests++
+	r.suite.Failures += len(tc.Failures)
+	r.suite.Errors += len(tc.Errors)
+	r.suite.TestCases = append(r.suite.TestCases, tc)
+
+	return nil
+}
+
+// Close writes the JUnit document.
+func (r *junitReporter) Close(_ context.Context) error {
+	r.suite.Name = toolName
+
+	return writeXML(r.w, junitTestSuites{
+		TestSuites: []junitTestSuite{r.suite},
+	})
+}
+
+// writeXML writes the XML header and the indented document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("cannot write XML header: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("cannot encode XML: %w", err)
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("cannot write XML footer: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package eclint_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+func reportXML(t *testing.T, format string) []byte {
+	t.Helper()
+
+	ctx := context.TODO()
+	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+
+	r, err := eclint.NewReporter(&eclint.Option{Format: format, Stdout: buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Report(ctx, "a.txt", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.Report(ctx, "b.txt", []error{
+		eclint.ValidationError{
+			Rule:     eclint.RuleEndOfLine,
+			Message:  "line does not end with lf (`\\n`)",
+			Line:     []byte("Hello\r\n"),
+			Index:    0,
+			Position: 7,
+		},
+		eclint.ValidationError{
+			Rule:     eclint.RuleTrimTrailingWhitespace,
+			Message:  "line has some trailing whitespaces",
+			Line:     []byte("World \n"),
+			Index:    1,
+			Position: 5,
+		},
+		errors.New("random <error>"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestCheckstyleReporter(t *testing.T) {
+	var doc struct {
+		Files []struct {
+			Name   string `xml:"name,attr"`
+			Errors []struct {
+				Line   int    `xml:"line,attr"`
+				Column int    `xml:"column,attr"`
+				Source string `xml:"source,attr"`
+			} `xml:"error"`
+		} `xml:"file"`
+	}
+
+	out := reportXML(t, eclint.FormatCheckstyle)
+	if err := xml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("cannot decode %q: %s", out, err)
+	}
+
+	if len(doc.Files) != 2 {
+		t.Fatalf("two files were expected, got %d", len(doc.Files))
+	}
+
+	if len(doc.Files[0].Errors) != 0 || len(doc.Files[1].Errors) != 3 {
+		t.Fatalf("unexpected errors, got %q", out)
+	}
+
+	e := doc.Files[1].Errors[1]
+	if e.Line != 2 || e.Column != 6 || e.Source != "eclint.trim_trailing_whitespace" {
+		t.Errorf("unexpected error, got %+v", e)
+	}
+}
+
+func TestJUnitReporter(t *testing.T) {
+	var doc struct {
+		TestSuites []struct {
+			Tests     int `xml:"tests,attr"`
+			Failures  int `xml:"failures,attr"`
+			Errors    int `xml:"errors,attr"`
+			TestCases []struct {
+				Name     string `xml:"name,attr"`
+				Failures []struct {
+					Type string `xml:"type,attr"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+
+	out := reportXML(t, eclint.FormatJUnit)
+	if err := xml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("cannot decode %q: %s", out, err)
+	}
+
+	if len(doc.TestSuites) != 1 {
+		t.Fatalf("one testsuite was expected, got %d", len(doc.TestSuites))
+	}
+
+	ts := doc.TestSuites[0]
+	if ts.Tests != 2 || ts.Failures != 2 || ts.Errors != 1 {
+		t.Errorf("unexpected counts, got %d tests, %d failures, %d errors", ts.Tests, ts.Failures, ts.Errors)
+	}
+
+	if ts.TestCases[1].Name != "b.txt" || ts.TestCases[1].Failures[0].Type != eclint.RuleEndOfLine {
+		t.Errorf("unexpected testcase, got %+v", ts.TestCases[1])
+	}
+}
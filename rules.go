@@ -0,0 +1,145 @@
+package eclint
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Rule identifiers, they are stable and named after the EditorConfig
+// property being checked.
+const (
+	RuleCharset                = "charset"
+	RuleEndOfLine              = "end_of_line"
+	RuleIndentSize             = "indent_size"
+	RuleIndentStyle            = "indent_style"
+	RuleInsertFinalNewline     = "insert_final_newline"
+	RuleMaxLineLength          = "max_line_length"
+	RuleTrimTrailingWhitespace = "trim_trailing_whitespace"
+	RuleBlockComment           = "block_comment"
+)
+
+// Severity tells how serious a violation is.
+type Severity string
+
+const (
+	// SeverityError is the default severity of a violation.
+	SeverityError Severity = "error"
+	// SeverityWarning is a violation that is reported but tolerated.
+	SeverityWarning Severity = "warning"
+)
+
+// Rule describes one of the checks.
+type Rule struct {
+	ID          string
+	Description string
+}
+
+// Rules lists all the checks performed by the linter.
+func Rules() []Rule {
+	return []Rule{
+		{RuleEndOfLine, "Lines end with the configured end_of_line."},
+		{RuleIndentStyle, "Lines are indented using the configured indent_style."},
+		{RuleIndentSize, "Lines are indented by a multiple of the configured indent_size."},
+		{RuleTrimTrailingWhitespace, "Lines have no trailing whitespaces."},
+		{RuleMaxLineLength, "Lines are not longer than max_line_length."},
+		{RuleCharset, "The file is encoded using the configured charset."},
+		{RuleInsertFinalNewline, "The file ends, or not, with a newline as insert_final_newline says."},
+		{RuleBlockComment, "Lines within a block comment start with the block_comment prefix."},
+	}
+}
+
+// ErrUnknownRule is returned when a rule identifier doesn't exist.
+var ErrUnknownRule = errors.New("unknown rule")
+
+// RuleSet holds the rules that were disabled and the severity overrides.
+//
+// A nil RuleSet enables every rule as an error.
+type RuleSet struct {
+	disabled   map[string]bool
+	severities map[string]Severity
+}
+
+// NewRuleSet builds a RuleSet from the disabled rules and the ones that are
+// only warnings.
+func NewRuleSet(disabled []string, warnings []string) (*RuleSet, error) {
+	return newRuleSet(Rules(), disabled, warnings)
+}
+
+// NewConfigRuleSet builds the RuleSet of the checks of the .editorconfig
+// files, see ConfigRules.
+func NewConfigRuleSet(disabled []string, warnings []string) (*RuleSet, error) {
+	return newRuleSet(ConfigRules(), disabled, warnings)
+}
+
+// newRuleSet builds a RuleSet, the rules have to be known ones.
+func newRuleSet(rules []Rule, disabled []string, warnings []string) (*RuleSet, error) {
+	known := make(map[string]bool)
+	for _, rule := range rules {
+		known[rule.ID] = true
+	}
+
+	rs := &RuleSet{
+		disabled:   make(map[string]bool),
+		severities: make(map[string]Severity),
+	}
+
+	for _, id := range disabled {
+		if !known[id] {
+			return nil, fmt.Errorf("%w: %q cannot be disabled", ErrUnknownRule, id)
+		}
+
+		rs.disabled[id] = true
+	}
+
+	for _, id := range warnings {
+		if !known[id] {
+			return nil, fmt.Errorf("%w: %q cannot be a warning", ErrUnknownRule, id)
+		}
+
+		rs.severities[id] = SeverityWarning
+	}
+
+	return rs, nil
+}
+
+// Enabled tells whether the rule has to be checked.
+func (rs *RuleSet) Enabled(rule string) bool {
+	if rs == nil {
+		return true
+	}
+
+	return !rs.disabled[rule]
+}
+
+// Severity returns the severity given to the rule.
+func (rs *RuleSet) Severity(rule string) Severity {
+	if rs == nil {
+		return SeverityError
+	}
+
+	if s, ok := rs.severities[rule]; ok {
+		return s
+	}
+
+	return SeverityError
+}
+
+// CountErrors counts the errors, leaving out the warnings.
+func CountErrors(errs []error) int {
+	c := 0
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var ve ValidationError
+		if ok := errors.As(err, &ve); ok && ve.severity() == SeverityWarning {
+			continue
+		}
+
+		c++
+	}
+
+	return c
+}
@@ -0,0 +1,72 @@
+package eclint_test
+
+import (
+	"errors"
+	"testing"
+
+	"gitlab.com/greut/eclint"
+)
+
+func TestNewRuleSet(t *testing.T) {
+	rs, err := eclint.NewRuleSet(
+		[]string{eclint.RuleMaxLineLength, eclint.RuleCharset},
+		[]string{eclint.RuleTrimTrailingWhitespace},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rs.Enabled(eclint.RuleMaxLineLength) || !rs.Enabled(eclint.RuleEndOfLine) {
+		t.Error("only max_line_length and charset should be disabled")
+	}
+
+	if rs.Severity(eclint.RuleTrimTrailingWhitespace) != eclint.SeverityWarning {
+		t.Error("trim_trailing_whitespace should be a warning")
+	}
+
+	if rs.Severity(eclint.RuleEndOfLine) != eclint.SeverityError {
+		t.Error("end_of_line should be an error")
+	}
+
+	var nilRules *eclint.RuleSet
+	if !nilRules.Enabled(eclint.RuleCharset) || nilRules.Severity(eclint.RuleCharset) != eclint.SeverityError {
+		t.Error("a nil rule set should enable everything as errors")
+	}
+}
+
+func TestNewRuleSetFailure(t *testing.T) {
+	if _, err := eclint.NewRuleSet([]string{"max_line_lenght"}, nil); !errors.Is(err, eclint.ErrUnknownRule) {
+		t.Errorf("an unknown rule error was expected, got %v", err)
+	}
+
+	if _, err := eclint.NewRuleSet(nil, []string{"indent"}); !errors.Is(err, eclint.ErrUnknownRule) {
+		t.Errorf("an unknown rule error was expected, got %v", err)
+	}
+
+	// The checks of the .editorconfig files are kept apart.
+	if _, err := eclint.NewRuleSet([]string{eclint.RuleShadowedProperty}, nil); !errors.Is(err, eclint.ErrUnknownRule) {
+		t.Errorf("an unknown rule error was expected, got %v", err)
+	}
+
+	if _, err := eclint.NewConfigRuleSet([]string{eclint.RuleCharset}, nil); !errors.Is(err, eclint.ErrUnknownRule) {
+		t.Errorf("an unknown rule error was expected, got %v", err)
+	}
+
+	if _, err := eclint.NewConfigRuleSet([]string{eclint.RuleShadowedProperty}, nil); err != nil {
+		t.Errorf("no errors were expected, got %v", err)
+	}
+}
+
+func TestCountErrors(t *testing.T) {
+	errs := []error{
+		eclint.ValidationError{Severity: eclint.SeverityError},
+		eclint.ValidationError{Severity: eclint.SeverityWarning},
+		eclint.ValidationError{},
+		errors.New("random error"),
+		nil,
+	}
+
+	if c := eclint.CountErrors(errs); c != 3 {
+		t.Errorf("three errors were expected, got %d", c)
+	}
+}
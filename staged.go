@@ -0,0 +1,69 @@
+package eclint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/go-logr/logr"
+)
+
+// GitStagedFilesContext returns the list of files added, copied, modified or
+// renamed in the index (asynchronously).
+//
+// The staged content is what will be committed, it may differ from the
+// working tree, see GitStagedContent. The submodules are skipped.
+func GitStagedFilesContext(ctx context.Context) (<-chan string, <-chan error) {
+	filesChan := make(chan string, 128)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(filesChan)
+		defer close(errChan)
+
+		log := logr.FromContextOrDiscard(ctx)
+
+		files, err := gitDiffNames(ctx, "--cached")
+		if err != nil {
+			errChan <- err
+
+			return
+		}
+
+		for _, f := range files {
+			// A file removed from the working tree still has its staged content.
+			if stat, err := os.Lstat(f); err == nil && stat.IsDir() {
+				log.V(1).Info("skipped directory", "filename", f)
+
+				continue
+			}
+
+			select {
+			case filesChan <- f:
+				// everything is good
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return filesChan, errChan
+}
+
+// GitStagedContent reads the content of the file from the index.
+func GitStagedContent(ctx context.Context, filename string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, "git", "cat-file", "blob", ":./"+filename).Output() //nolint:gosec
+	if err != nil {
+		var e *exec.ExitError
+		if ok := errors.As(err, &e); ok {
+			err = fmt.Errorf("git cat-file %s failed with %s: %w", filename, bytes.TrimSpace(e.Stderr), e)
+		}
+
+		return nil, err
+	}
+
+	return output, nil
+}
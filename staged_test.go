@@ -0,0 +1,77 @@
+package eclint_test
+
+import (
+	"context"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/greut/eclint"
+)
+
+func TestGitStaged(t *testing.T) { //nolint:paralleltest
+	git := gitRepo(t)
+
+	write := func(filename, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a.txt", "a\n")
+	write("b.txt", "b\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "base")
+
+	// staged: a modified then edited again, c added then removed, d added.
+	write("a.txt", "a \n")
+	write("c.txt", "c\n")
+	write("d.txt", "d\n")
+	git("add", ".")
+	write("a.txt", "a\n")
+
+	if err := os.Remove("c.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	// unstaged
+	write("b.txt", "bb\n")
+
+	files := make([]string, 0)
+	fsChan, errChan := eclint.GitStagedFilesContext(context.TODO())
+
+	for f := range fsChan {
+		files = append(files, f)
+	}
+
+	if err := <-errChan; err != nil {
+		t.Fatalf("no errors were expected, got %s", err)
+	}
+
+	sort.Strings(files)
+
+	expected := []string{"a.txt", "c.txt", "d.txt"}
+	if !cmp.Equal(expected, files) {
+		t.Errorf("diff %s", cmp.Diff(expected, files))
+	}
+
+	content, err := eclint.GitStagedContent(context.TODO(), "a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "a \n" {
+		t.Errorf("the staged content was expected, got %q", content)
+	}
+
+	if _, err := eclint.GitStagedContent(context.TODO(), "b.txt"); err != nil {
+		t.Errorf("no errors were expected for a committed file, got %s", err)
+	}
+
+	if _, err := eclint.GitStagedContent(context.TODO(), "e.txt"); err == nil {
+		t.Error("an error was expected for an unknown file")
+	}
+}
@@ -0,0 +1,222 @@
+package eclint
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Inline directives to suppress the violations.
+//
+//	eclint-disable-next-line [rule, ...]
+//	eclint-disable-line [rule, ...]
+//	eclint-disable [rule, ...]
+//	eclint-enable [rule, ...]
+//
+// Without any rules, all of them are concerned.
+const (
+	directivePrefix          = "eclint-"
+	directiveDisableNextLine = "eclint-disable-next-line"
+	directiveDisableLine     = "eclint-disable-line"
+	directiveDisable         = "eclint-disable"
+	directiveEnable          = "eclint-enable"
+)
+
+// ruleFlags holds a set of rules, it may contain all of them.
+type ruleFlags struct {
+	all    bool
+	rules  map[string]bool
+	except map[string]bool
+}
+
+// has tells whether the rule is part of the set.
+func (f *ruleFlags) has(rule string) bool {
+	if f.except[rule] {
+		return false
+	}
+
+	return f.all || f.rules[rule]
+}
+
+// add puts the rules into the set, no rules means all of them.
+func (f *ruleFlags) add(rules []string) {
+	if len(rules) == 0 {
+		*f = ruleFlags{all: true}
+
+		return
+	}
+
+	if f.rules == nil {
+		f.rules = make(map[string]bool)
+	}
+
+	for _, rule := range rules {
+		f.rules[rule] = true
+		delete(f.except, rule)
+	}
+}
+
+// remove takes the rules out of the set, no rules means all of them.
+func (f *ruleFlags) remove(rules []string) {
+	if len(rules) == 0 {
+		*f = ruleFlags{}
+
+		return
+	}
+
+	if f.except == nil {
+		f.except = make(map[string]bool)
+	}
+
+	for _, rule := range rules {
+		delete(f.rules, rule)
+
+		if f.all {
+			f.except[rule] = true
+		}
+	}
+}
+
+// lineSuppressions are the sets of rules suppressed on a given line.
+type lineSuppressions []ruleFlags
+
+// has tells whether the rule is suppressed.
+func (l lineSuppressions) has(rule string) bool {
+	for _, f := range l {
+		if f.has(rule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suppressions keeps track of the inline directives while reading the lines.
+type suppressions struct {
+	// markers are the comment starts, when empty any substring matches.
+	markers  [][]byte
+	blockEnd []byte
+	nextLine ruleFlags
+	block    ruleFlags
+}
+
+// newSuppressions builds the tracker using the line_comment and
+// block_comment_start domain-specific properties.
+func newSuppressions(def *definition) *suppressions {
+	s := &suppressions{}
+
+	for _, key := range []string{"line_comment", "block_comment_start"} {
+		if v := def.Raw[key]; v != "" && v != UnsetValue {
+			s.markers = append(s.markers, []byte(v))
+		}
+	}
+
+	if v := def.Raw["block_comment_end"]; v != "" && v != UnsetValue {
+		s.blockEnd = []byte(v)
+	}
+
+	return s
+}
+
+// scan reads the directives of the line and returns the rules suppressed on it.
+func (s *suppressions) scan(data []byte) lineSuppressions {
+	line := lineSuppressions{s.nextLine}
+	s.nextLine = ruleFlags{}
+
+	directive, rules := s.directive(data)
+
+	switch directive {
+	case directiveDisableNextLine:
+		s.nextLine.add(rules)
+	case directiveDisableLine:
+		f := ruleFlags{}
+		f.add(rules)
+		line = append(line, f)
+	case directiveDisable:
+		s.block.add(rules)
+	case directiveEnable:
+		s.block.remove(rules)
+	}
+
+	return append(line, s.block)
+}
+
+// directive finds the first directive within a comment of the line.
+func (s *suppressions) directive(data []byte) (string, []string) {
+	text := data
+
+	if len(s.markers) > 0 {
+		start := -1
+
+		for _, marker := range s.markers {
+			if i := bytes.Index(data, marker); i >= 0 && (start < 0 || i+len(marker) < start) {
+				start = i + len(marker)
+			}
+		}
+
+		if start < 0 {
+			return "", nil
+		}
+
+		text = data[start:]
+	}
+
+	for i := bytes.Index(text, []byte(directivePrefix)); i >= 0; i = bytes.Index(text, []byte(directivePrefix)) {
+		text = text[i:]
+
+		for _, directive := range []string{
+			directiveDisableNextLine,
+			directiveDisableLine,
+			directiveDisable,
+			directiveEnable,
+		} {
+			if !bytes.HasPrefix(text, []byte(directive)) {
+				continue
+			}
+
+			rest := text[len(directive):]
+			if len(rest) > 0 && !isDirectiveSeparator(rune(rest[0])) {
+				continue
+			}
+
+			return directive, s.directiveRules(rest)
+		}
+
+		text = text[len(directivePrefix):]
+	}
+
+	return "", nil
+}
+
+// directiveRules parses the list of rules following a directive.
+//
+// The parsing stops at the first word which isn't a known rule, this way
+// a free text explanation may follow.
+func (s *suppressions) directiveRules(rest []byte) []string {
+	if len(s.blockEnd) > 0 {
+		if i := bytes.Index(rest, s.blockEnd); i >= 0 {
+			rest = rest[:i]
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, rule := range Rules() {
+		known[rule.ID] = true
+	}
+
+	rules := make([]string, 0)
+
+	for _, word := range strings.FieldsFunc(string(rest), isDirectiveSeparator) {
+		if !known[word] {
+			break
+		}
+
+		rules = append(rules, word)
+	}
+
+	return rules
+}
+
+// isDirectiveSeparator tells whether the character separates the directive words.
+func isDirectiveSeparator(r rune) bool {
+	return r == space || r == tab || r == ',' || r == cr || r == lf
+}
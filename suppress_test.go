@@ -0,0 +1,88 @@
+package eclint
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/editorconfig/editorconfig-core-go/v2"
+)
+
+func TestSuppressions(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Raw    map[string]string
+		File   string
+		Errors int
+	}{
+		{
+			Name:   "no directives",
+			Raw:    map[string]string{"line_comment": "//"},
+			File:   "short\nway too long\n",
+			Errors: 1,
+		}, {
+			Name:   "disable next line",
+			Raw:    map[string]string{"line_comment": "//"},
+			File:   "// eclint-disable-next-line max_line_length\nway too long\nway too long\n",
+			Errors: 2,
+		}, {
+			Name:   "disable line",
+			Raw:    map[string]string{"line_comment": "#"},
+			File:   "way too long # eclint-disable-line\nway too long\n",
+			Errors: 1,
+		}, {
+			Name:   "disable another rule",
+			Raw:    map[string]string{"line_comment": "#"},
+			File:   "way too long # eclint-disable-line trim_trailing_whitespace\n",
+			Errors: 1,
+		}, {
+			Name:   "block",
+			Raw:    map[string]string{"block_comment_start": "/*", "block_comment_end": "*/"},
+			File:   "/* eclint-disable max_line_length */\nway too long\nway too long\n/* eclint-enable */\nway too long\n",
+			Errors: 2,
+		}, {
+			Name:   "block with exception",
+			Raw:    map[string]string{"block_comment_start": "/*", "block_comment_end": "*/"},
+			File:   "/* eclint-disable */\nway too long\n/* eclint-enable max_line_length */\nway too long\n",
+			Errors: 2,
+		}, {
+			Name:   "outside of a comment",
+			Raw:    map[string]string{"line_comment": "//"},
+			File:   "way too long eclint-disable-line\n",
+			Errors: 1,
+		}, {
+			Name:   "substring",
+			Raw:    map[string]string{},
+			File:   "way too long eclint-disable-line max_line_length, because\n",
+			Errors: 0,
+		}, {
+			Name:   "unknown directive",
+			Raw:    map[string]string{},
+			File:   "way too long eclint-disabled\n",
+			Errors: 1,
+		},
+	}
+
+	ctx := context.TODO()
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &editorconfig.Definition{Raw: tc.Raw}
+			d.Raw["max_line_length"] = "10"
+
+			def, err := newDefinition(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			errs := validate(ctx, bytes.NewReader([]byte(tc.File)), -1, "utf-8", def)
+			if len(errs) != tc.Errors {
+				t.Errorf("%d errors were expected, got %d: %v", tc.Errors, len(errs), errs)
+			}
+		})
+	}
+}
@@ -28,6 +28,8 @@ var ErrConfiguration = errors.New("configuration error")
 
 // ValidationError is a rich type containing information about the error.
 type ValidationError struct {
+	Rule     string
+	Severity Severity
 	Message  string
 	Filename string
 	Line     []byte
@@ -35,6 +37,15 @@ type ValidationError struct {
 	Position int
 }
 
+// severity returns the Severity, an unset one is an error.
+func (e ValidationError) severity() Severity {
+	if e.Severity == "" {
+		return SeverityError
+	}
+
+	return e.Severity
+}
+
 func (e ValidationError) String() string {
 	return e.Error()
 }
@@ -50,6 +61,8 @@ func endOfLine(eol string, data []byte) error {
 	case editorconfig.EndOfLineLf:
 		if !bytes.HasSuffix(data, []byte{lf}) || bytes.HasSuffix(data, []byte{cr, lf}) {
 			return ValidationError{
+				Rule:     RuleEndOfLine,
+				Severity: SeverityError,
 				Message:  "line does not end with lf (`\\n`)",
 				Position: len(data),
 			}
@@ -57,6 +70,8 @@ func endOfLine(eol string, data []byte) error {
 	case editorconfig.EndOfLineCrLf:
 		if !bytes.HasSuffix(data, []byte{cr, lf}) && !bytes.HasSuffix(data, []byte{0x00, cr, 0x00, lf}) {
 			return ValidationError{
+				Rule:     RuleEndOfLine,
+				Severity: SeverityError,
 				Message:  "line does not end with crlf (`\\r\\n`)",
 				Position: len(data),
 			}
@@ -64,6 +79,8 @@ func endOfLine(eol string, data []byte) error {
 	case editorconfig.EndOfLineCr:
 		if !bytes.HasSuffix(data, []byte{cr}) {
 			return ValidationError{
+				Rule:     RuleEndOfLine,
+				Severity: SeverityError,
 				Message:  "line does not end with cr (`\\r`)",
 				Position: len(data),
 			}
@@ -110,6 +127,8 @@ func indentStyle(style string, size int, data []byte) error {
 
 		if data[i] == x {
 			return ValidationError{
+				Rule:     RuleIndentStyle,
+				Severity: SeverityError,
 				Message:  fmt.Sprintf("indentation style mismatch expected %q (%s) got %q", c, style, x),
 				Position: i,
 			}
@@ -120,6 +139,8 @@ func indentStyle(style string, size int, data []byte) error {
 		}
 
 		return ValidationError{
+			Rule:     RuleIndentSize,
+			Severity: SeverityError,
 			Message:  fmt.Sprintf("indentation size doesn't match expected %d, got %d", size, i),
 			Position: i,
 		}
@@ -138,6 +159,8 @@ func checkInsertFinalNewline(data []byte, insertFinalNewline bool) error {
 	if lastChar != cr && lastChar != lf {
 		if insertFinalNewline {
 			return ValidationError{
+				Rule:     RuleInsertFinalNewline,
+				Severity: SeverityError,
 				Message:  "the final newline is missing",
 				Position: len(data),
 			}
@@ -145,6 +168,8 @@ func checkInsertFinalNewline(data []byte, insertFinalNewline bool) error {
 	} else {
 		if !insertFinalNewline {
 			return ValidationError{
+				Rule:     RuleInsertFinalNewline,
+				Severity: SeverityError,
 				Message:  "an extraneous final newline was found",
 				Position: len(data),
 			}
@@ -163,6 +188,8 @@ func checkTrimTrailingWhitespace(data []byte) error {
 
 		if data[i] == space || data[i] == tab {
 			return ValidationError{
+				Rule:     RuleTrimTrailingWhitespace,
+				Severity: SeverityError,
 				Message:  "line has some trailing whitespaces",
 				Position: i,
 			}
@@ -196,6 +223,8 @@ func checkBlockComment(i int, prefix []byte, data []byte) error {
 
 		if !bytes.HasPrefix(data[i:], prefix) {
 			return ValidationError{
+				Rule:     RuleBlockComment,
+				Severity: SeverityError,
 				Message:  fmt.Sprintf("block_comment prefix %q was expected inside a block comment", string(prefix)),
 				Position: i,
 			}
@@ -250,6 +279,8 @@ func MaxLineLength(maxLength int, tabWidth int, data []byte) error {
 
 	if length > maxLength {
 		return ValidationError{
+			Rule:     RuleMaxLineLength,
+			Severity: SeverityError,
 			Message:  fmt.Sprintf("line is too long (%d > %d)", length, maxLength),
 			Position: breakingPosition,
 		}
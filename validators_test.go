@@ -376,3 +376,60 @@ func TestMaxLineLengthFailure(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationErrorRule(t *testing.T) {
+	tests := []struct {
+		Name string
+		Rule string
+		Err  error
+	}{
+		{
+			Name: "end of line",
+			Rule: RuleEndOfLine,
+			Err:  endOfLine("lf", []byte("\r\n")),
+		}, {
+			Name: "indent style",
+			Rule: RuleIndentStyle,
+			Err:  indentStyle("space", 2, []byte("\t.")),
+		}, {
+			Name: "indent size",
+			Rule: RuleIndentSize,
+			Err:  indentStyle("space", 2, []byte("   .")),
+		}, {
+			Name: "insert final newline",
+			Rule: RuleInsertFinalNewline,
+			Err:  checkInsertFinalNewline([]byte("."), true),
+		}, {
+			Name: "trim trailing whitespace",
+			Rule: RuleTrimTrailingWhitespace,
+			Err:  checkTrimTrailingWhitespace([]byte(". \n")),
+		}, {
+			Name: "block comment",
+			Rule: RuleBlockComment,
+			Err:  checkBlockComment(0, []byte("*"), []byte(" .\n")),
+		}, {
+			Name: "max line length",
+			Rule: RuleMaxLineLength,
+			Err:  MaxLineLength(1, 8, []byte("..\n")),
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var ve ValidationError
+			if ok := errors.As(tc.Err, &ve); !ok {
+				t.Fatalf("a ValidationError was expected, got %v", tc.Err)
+			}
+
+			if ve.Rule != tc.Rule {
+				t.Errorf("rule mismatch %q, got %q", tc.Rule, ve.Rule)
+			}
+
+			if ve.Severity != SeverityError {
+				t.Errorf("severity mismatch %q, got %q", SeverityError, ve.Severity)
+			}
+		})
+	}
+}
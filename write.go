@@ -0,0 +1,169 @@
+package eclint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+)
+
+// errMetadata is returned when the temporary file cannot be given the
+// ownership or the extended attributes of the original one.
+var errMetadata = errors.New("cannot preserve the metadata")
+
+// writeFile replaces the content of the file using the reader, a copy of the
+// original is kept using the backup suffix, unless it's empty.
+//
+// Symbolic links are followed so that the file they point to is modified.
+// The content goes into a temporary file of the same directory which is
+// synced, given the mode, ownership and extended attributes of the original,
+// and renamed over it. A file having other hard links, or whose metadata
+// cannot be kept, is rewritten in place instead as a rename would detach it
+// from its links.
+func writeFile(ctx context.Context, filename string, r io.Reader, backupSuffix string) (int64, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	target, err := filepath.EvalSymlinks(filename)
+	if err != nil {
+		return 0, fmt.Errorf("cannot resolve %s: %w", filename, err)
+	}
+
+	stat, err := os.Stat(target)
+	if err != nil {
+		return 0, fmt.Errorf("cannot stat %s: %w", target, err)
+	}
+
+	if backupSuffix != "" {
+		if err := copyFile(target, target+backupSuffix, stat.Mode()); err != nil {
+			return 0, err
+		}
+
+		log.V(1).Info("backup written", "filename", target+backupSuffix)
+	}
+
+	if linkCount(stat) > 1 {
+		log.V(1).Info("file has hard links, writing in place", "filename", target)
+
+		return writeFileInPlace(target, stat.Mode(), r)
+	}
+
+	n, err := writeFileAtomic(target, stat, r)
+	if errors.Is(err, errMetadata) {
+		log.V(1).Info("metadata cannot be kept, writing in place", "filename", target, "error", err.Error())
+
+		return writeFileInPlace(target, stat.Mode(), r)
+	}
+
+	return n, err
+}
+
+// writeFileAtomic writes the content into a temporary file and renames it,
+// the directory is synced so that the rename survives a crash.
+//
+// When errMetadata is returned, the reader hasn't been consumed.
+func writeFileAtomic(target string, stat os.FileInfo, r io.Reader) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".eclint-*")
+	if err != nil {
+		return 0, fmt.Errorf("cannot create a temporary file for %s: %w", target, err)
+	}
+
+	done := false
+
+	defer func() {
+		if !done {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err := chown(tmp, stat); err != nil {
+		return 0, fmt.Errorf("%w of %s: %w", errMetadata, target, err)
+	}
+
+	if err := copyXattrs(tmp, target); err != nil {
+		return 0, fmt.Errorf("%w of %s: %w", errMetadata, target, err)
+	}
+
+	if err := tmp.Chmod(fileMode(stat.Mode())); err != nil {
+		return 0, fmt.Errorf("cannot change the mode of %s: %w", tmp.Name(), err)
+	}
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		return 0, fmt.Errorf("error copying file: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return 0, fmt.Errorf("cannot sync %s: %w", tmp.Name(), err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("cannot close %s: %w", tmp.Name(), err)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return 0, fmt.Errorf("cannot rename %s to %s: %w", tmp.Name(), target, err)
+	}
+
+	done = true
+
+	if err := syncDir(filepath.Dir(target)); err != nil {
+		return n, err
+	}
+
+	return n, nil
+}
+
+// writeFileInPlace truncates the file and writes the content into it.
+func writeFileInPlace(target string, mode os.FileMode, r io.Reader) (int64, error) {
+	fp, err := os.OpenFile(target, os.O_WRONLY|os.O_TRUNC, mode) //nolint:nosnakecase
+	if err != nil {
+		return 0, fmt.Errorf("cannot open %s using %s: %w", target, mode, err)
+	}
+	defer fp.Close()
+
+	n, err := io.Copy(fp, r)
+	if err != nil {
+		return 0, fmt.Errorf("error copying file: %w", err)
+	}
+
+	if err := fp.Sync(); err != nil {
+		return 0, fmt.Errorf("cannot sync %s: %w", target, err)
+	}
+
+	return n, nil
+}
+
+// copyFile copies the content of src into dst, which is overwritten.
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("cannot open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode(mode)) //nolint:nosnakecase
+	if err != nil {
+		return fmt.Errorf("cannot create %s: %w", dst, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("cannot copy %s to %s: %w", src, dst, err)
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("cannot close %s: %w", dst, err)
+	}
+
+	return nil
+}
+
+// fileMode keeps the permissions and the special bits of the mode.
+func fileMode(mode os.FileMode) os.FileMode {
+	return mode & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky)
+}
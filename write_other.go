@@ -0,0 +1,22 @@
+//go:build !unix
+
+package eclint
+
+import (
+	"os"
+)
+
+// chown does nothing as the ownership isn't a unix one.
+func chown(_ *os.File, _ os.FileInfo) error {
+	return nil
+}
+
+// linkCount cannot tell the number of hard links.
+func linkCount(_ os.FileInfo) uint64 {
+	return 1
+}
+
+// syncDir does nothing as the directories cannot be synced.
+func syncDir(_ string) error {
+	return nil
+}
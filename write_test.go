@@ -0,0 +1,123 @@
+package eclint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	ctx := context.TODO()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "a.txt")
+
+	if err := os.WriteFile(filename, []byte("hello \n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chmod(filename, 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := writeFile(ctx, filename, strings.NewReader("hello\n"), ".orig")
+	if err != nil {
+		t.Fatalf("no errors were expected, got %s", err)
+	}
+
+	if n != 6 {
+		t.Errorf("6 bytes were expected to be written, got %d", n)
+	}
+
+	assertFile(t, filename, "hello\n")
+	assertFile(t, filename+".orig", "hello \n")
+
+	stat, err := os.Stat(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if runtime.GOOS != "windows" && stat.Mode().Perm() != 0o640 {
+		t.Errorf("the mode was expected to be kept, got %s", stat.Mode())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Errorf("no temporary files were expected to be left, got %d entries", len(entries))
+	}
+}
+
+func TestWriteFileSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links are not supported")
+	}
+
+	ctx := context.TODO()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "a.txt")
+	symlink := filepath.Join(dir, "symlink.txt")
+
+	if err := os.WriteFile(filename, []byte("hello \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink("a.txt", symlink); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writeFile(ctx, symlink, strings.NewReader("hello\n"), ""); err != nil {
+		t.Fatalf("no errors were expected, got %s", err)
+	}
+
+	stat, err := os.Lstat(symlink)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stat.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("the symbolic link was expected to be kept, got %s", stat.Mode())
+	}
+
+	assertFile(t, filename, "hello\n")
+}
+
+func TestWriteFileHardlink(t *testing.T) {
+	ctx := context.TODO()
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "a.txt")
+	hardlink := filepath.Join(dir, "hardlink.txt")
+
+	if err := os.WriteFile(filename, []byte("hello \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Link(filename, hardlink); err != nil {
+		t.Skipf("hard links are not supported: %s", err)
+	}
+
+	if _, err := writeFile(ctx, filename, strings.NewReader("hello\n"), ""); err != nil {
+		t.Fatalf("no errors were expected, got %s", err)
+	}
+
+	assertFile(t, filename, "hello\n")
+	assertFile(t, hardlink, "hello\n")
+}
+
+func assertFile(t *testing.T, filename string, expected string) {
+	t.Helper()
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != expected {
+		t.Errorf("%s: %q was expected, got %q", filename, expected, content)
+	}
+}
@@ -0,0 +1,57 @@
+//go:build unix
+
+package eclint
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// chown gives the file the owner and group of the original one.
+func chown(fp *os.File, stat os.FileInfo) error {
+	want, ok := stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	tmpStat, err := fp.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot stat %s: %w", fp.Name(), err)
+	}
+
+	if got, ok := tmpStat.Sys().(*syscall.Stat_t); ok && got.Uid == want.Uid && got.Gid == want.Gid {
+		return nil
+	}
+
+	if err := fp.Chown(int(want.Uid), int(want.Gid)); err != nil {
+		return fmt.Errorf("cannot chown %s: %w", fp.Name(), err)
+	}
+
+	return nil
+}
+
+// linkCount returns the number of hard links of the file.
+func linkCount(stat os.FileInfo) uint64 {
+	if s, ok := stat.Sys().(*syscall.Stat_t); ok {
+		return uint64(s.Nlink) //nolint:unconvert
+	}
+
+	return 1
+}
+
+// syncDir flushes the directory entries, e.g. a rename, to the disk.
+func syncDir(dir string) error {
+	fp, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("cannot open %s: %w", dir, err)
+	}
+
+	defer fp.Close()
+
+	if err := fp.Sync(); err != nil {
+		return fmt.Errorf("cannot sync %s: %w", dir, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+//go:build linux || darwin
+
+package eclint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// copyXattrs gives the file the extended attributes of the original one.
+func copyXattrs(fp *os.File, target string) error {
+	names, err := readXattr(func(dest []byte) (int, error) {
+		return unix.Listxattr(target, dest)
+	})
+	if errors.Is(err, unix.ENOTSUP) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot list the extended attributes of %s: %w", target, err)
+	}
+
+	for _, name := range bytes.Split(names, []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+
+		attr := string(name)
+
+		value, err := readXattr(func(dest []byte) (int, error) {
+			return unix.Getxattr(target, attr, dest)
+		})
+		if err != nil {
+			return fmt.Errorf("cannot get the %s attribute of %s: %w", attr, target, err)
+		}
+
+		if err := unix.Fsetxattr(int(fp.Fd()), attr, value, 0); err != nil {
+			return fmt.Errorf("cannot set the %s attribute of %s: %w", attr, fp.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// readXattr calls the function twice, to get the size of the value and then
+// the value itself.
+func readXattr(fn func(dest []byte) (int, error)) ([]byte, error) {
+	size, err := fn(nil)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+
+	dest := make([]byte, size)
+
+	n, err := fn(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	return dest[:n], nil
+}
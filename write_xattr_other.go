@@ -0,0 +1,12 @@
+//go:build !linux && !darwin
+
+package eclint
+
+import (
+	"os"
+)
+
+// copyXattrs does nothing, the extended attributes aren't kept.
+func copyXattrs(_ *os.File, _ string) error {
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build linux || darwin
+
+package eclint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestWriteFileXattrs(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a.txt")
+
+	if err := os.WriteFile(filename, []byte("hello \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := unix.Setxattr(filename, "user.eclint", []byte("kept"), 0); err != nil {
+		t.Skipf("extended attributes are not supported: %s", err)
+	}
+
+	if _, err := writeFile(context.TODO(), filename, strings.NewReader("hello\n"), ""); err != nil {
+		t.Fatalf("no errors were expected, got %s", err)
+	}
+
+	assertFile(t, filename, "hello\n")
+
+	value := make([]byte, 16)
+
+	n, err := unix.Getxattr(filename, "user.eclint", value)
+	if err != nil {
+		t.Fatalf("the extended attribute was expected to be kept, got %s", err)
+	}
+
+	if string(value[:n]) != "kept" {
+		t.Errorf("%q was expected, got %q", "kept", value[:n])
+	}
+}